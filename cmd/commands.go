@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"go.mod/internal/config"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const commandsUsage = `команды:
+  migrate up           применить все новые миграции
+  migrate down         откатить последнюю миграцию
+  migrate status       показать состояние миграций
+  migrate goto ВЕРСИЯ  перейти к указанной версии схемы (0 — откатить всё)
+  hash-passwords       захешировать пароли, сохранённые в открытом виде
+  purge                окончательно удалить записи, удалённые раньше срока retention.soft_deleted
+  apply-assignments    применить кадровые действия, дата которых наступила`
+
+// runCommand — выполнение подкоманд обслуживания базы данных
+func runCommand(db *database.Database, cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(db, args[1:])
+	case "hash-passwords":
+		// Одноразовая миграция: хеширование паролей, сохранённых в открытом виде
+		count, err := db.HashLegacyPasswords()
+		if err != nil {
+			return fmt.Errorf("ошибка хеширования паролей: %v", err)
+		}
+		log.Printf("Захешировано паролей: %d", count)
+		return nil
+	case "purge":
+		// Для запуска по расписанию (cron); то же, что POST /purge
+		before := time.Now().Add(-cfg.Retention.SoftDeleted)
+		meta := model.AuditMeta{Actor: "system"}
+		employees, err := db.PurgeEmployees(before, meta)
+		if err != nil {
+			return fmt.Errorf("ошибка очистки сотрудников: %v", err)
+		}
+		users, err := db.PurgeUsers(before, meta)
+		if err != nil {
+			return fmt.Errorf("ошибка очистки пользователей: %v", err)
+		}
+		log.Printf("Окончательно удалено (до %s): сотрудников %d, пользователей %d",
+			before.Format("2006-01-02 15:04:05"), employees, users)
+		return nil
+	case "apply-assignments":
+		// Для запуска по расписанию (cron), если сервер не применяет действия сам (assignments.apply_interval: 0)
+		count, err := db.ApplyJobAssignments(model.Today(), model.AuditMeta{Actor: "system"})
+		if err != nil {
+			return fmt.Errorf("ошибка применения кадровых действий: %v", err)
+		}
+		log.Printf("Применено кадровых действий: %d", count)
+		return nil
+	default:
+		return fmt.Errorf("неизвестная команда %q\n%s", args[0], commandsUsage)
+	}
+}
+
+func runMigrate(db *database.Database, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("не указано действие\n%s", commandsUsage)
+	}
+
+	migrator, err := database.NewMigrator(db.Connection)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down":
+		if err := migrator.Down(); err != nil {
+			return err
+		}
+	case "goto":
+		if len(args) < 2 {
+			return fmt.Errorf("не указана версия\n%s", commandsUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("некорректная версия %q", args[1])
+		}
+		if err := migrator.Goto(version); err != nil {
+			return err
+		}
+	case "status":
+	default:
+		return fmt.Errorf("неизвестное действие %q\n%s", args[0], commandsUsage)
+	}
+
+	return printMigrationStatus(migrator)
+}
+
+func printMigrationStatus(migrator *database.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ВЕРСИЯ\tМИГРАЦИЯ\tПРИМЕНЕНА")
+	for _, status := range statuses {
+		applied := "нет"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, applied)
+	}
+	return w.Flush()
+}
+
+// migrateUp — применение миграций при запуске сервера
+func migrateUp(db *database.Database) error {
+	migrator, err := database.NewMigrator(db.Connection)
+	if err != nil {
+		return err
+	}
+	return migrator.Up()
+}
@@ -1,43 +1,95 @@
 package main
 
 import (
-	"github.com/joho/godotenv"
+	"crypto/rand"
+	"encoding/base64"
 	_ "github.com/lib/pq"
+	"go.mod/internal/config"
 	"go.mod/internal/database"
 	"go.mod/internal/handler"
+	"go.mod/internal/model"
 	"go.mod/internal/server"
 	"go.mod/internal/service"
-	"go.mod/pkg"
 	_ "gorm.io/driver/postgres"
 	"log"
+	"os"
+	"time"
 )
 
 func main() {
-	// 1. Загружаем конфиг из config.yaml
-	if err := pkg.InitConfig(); err != nil {
-		log.Fatal("Ошибка загрузки конфига:", err)
+	// 1. Загружаем конфиг: config.yaml, переменные окружения (.env) и флаги командной строки
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal("Ошибка загрузки конфига: ", err)
 	}
 
-	// 2. Загружаем переменные окружения из .env
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Ошибка загрузки .env файла:", err)
+	// Даты (сегодняшний день для кадровых действий) считаются в часовом поясе приложения
+	if location, err := time.LoadLocation(cfg.TimeZone); err == nil {
+		time.Local = location
 	}
 
-	// 3. Подключение к базе данных
-	connection := database.NewConnectPostgres()
+	// 2. Создание хранилищ
+	var repo *database.Repository
+	switch cfg.DB.Driver {
+	case config.DriverMemory:
+		if len(cfg.Args) > 0 {
+			log.Fatalf("Команда %s недоступна для хранилища в памяти", cfg.Args[0])
+		}
+		repo = newDemoRepository()
+	default:
+		// Подключение к базе данных
+		connection := database.NewConnectPostgres(cfg)
+		db := database.NewDatabase(connection)
 
-	// 4. Создание объекта базы данных
-	db := database.NewDatabase(connection)
+		// Подкоманды обслуживания базы: migrate, hash-passwords, purge, apply-assignments
+		if len(cfg.Args) > 0 {
+			if err := runCommand(db, cfg, cfg.Args); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 
-	// 5. Создание сервисов (бизнес-логики)
-	services := service.NewService(db)
+		// Применение миграций при запуске (включается db.auto_migrate)
+		if cfg.DB.AutoMigrate {
+			if err := migrateUp(db); err != nil {
+				log.Fatal("Ошибка применения миграций: ", err)
+			}
+		}
 
-	// 6. Создание обработчиков
-	handler := handler.NewHandler(services, db) // исправил здесь
+		repo = database.NewRepository(db)
+	}
+
+	// 3. Создание сервисов (бизнес-логики)
+	services := service.NewService(repo, cfg.JWT, cfg.Login, cfg.Retention, cfg.Fields)
+
+	// Кадровые действия с будущей датой вступают в силу по расписанию
+	if cfg.Assignments.ApplyInterval > 0 {
+		go services.Employees.ApplyAssignmentsEvery(cfg.Assignments.ApplyInterval)
+	}
+
+	// 4. Создание обработчиков
+	handler := handler.NewHandler(services, cfg)
 
-	// 7. Создание и запуск сервера
+	// 5. Создание и запуск сервера
 	app := new(server.Server)
-	if err := app.ServerRun(handler.InitRoutes(), "8080"); err != nil {
+	if err := app.ServerRun(handler.InitRoutes(), cfg.Server); err != nil {
 		log.Fatal("Ошибка запуска сервера:", err)
 	}
 }
+
+// newDemoRepository — хранилище в памяти с администратором, пароль которого выводится в лог
+func newDemoRepository() *database.Repository {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal("Ошибка генерации пароля:", err)
+	}
+	adminPassword := base64.RawURLEncoding.EncodeToString(b)
+
+	memory := database.NewMemoryDatabase()
+	if _, err := memory.CreateUser(model.User{Username: "admin", Password: adminPassword, Roles: []string{model.RoleAdmin}}, model.AuditMeta{Actor: "system"}); err != nil {
+		log.Fatal("Ошибка создания администратора:", err)
+	}
+	log.Printf("Хранилище в памяти: данные не сохраняются. Вход: admin / %s", adminPassword)
+
+	return database.NewMemoryRepository(memory)
+}
@@ -0,0 +1,373 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.mod/internal/model"
+)
+
+// Config — конфигурация приложения.
+// Приоритет источников (от высшего к низшему): флаги командной строки, переменные окружения
+// (в том числе из .env), файл config.yaml, значения по умолчанию.
+// Имя переменной окружения получается из ключа: db.password -> DB_PASSWORD, jwt.secret -> JWT_SECRET.
+type Config struct {
+	Server      ServerConfig      `mapstructure:"server"`
+	DB          DBConfig          `mapstructure:"db"`
+	JWT         JWTConfig         `mapstructure:"jwt"`
+	Login       LoginConfig       `mapstructure:"login"`
+	Retention   RetentionConfig   `mapstructure:"retention"`
+	Assignments AssignmentsConfig `mapstructure:"assignments"`
+	Fields      FieldsConfig      `mapstructure:"fields"`
+	TimeZone    string            `mapstructure:"timezone"` // Часовой пояс приложения и соединения с базой
+
+	Args []string `mapstructure:"-"` // Позиционные аргументы командной строки (подкоманды)
+}
+
+// Настройки HTTP-сервера
+type ServerConfig struct {
+	Host         string        `mapstructure:"host"`
+	Port         string        `mapstructure:"port"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+}
+
+// Параметры хранилища
+type DBConfig struct {
+	Driver   string `mapstructure:"driver"` // postgres или memory (данные в памяти, для тестов и демо)
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	DBName   string `mapstructure:"dbname"`
+	SSLMode  string `mapstructure:"sslmode"`
+
+	AutoMigrate bool `mapstructure:"auto_migrate"` // Применять миграции при запуске сервера
+}
+
+// Настройки JWT
+type JWTConfig struct {
+	Secret     string        `mapstructure:"secret"`
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"`
+}
+
+// Защита входа от подбора пароля
+type LoginConfig struct {
+	// Неудачных попыток подряд, после которых учётная запись блокируется на lockout_duration (0 — не блокировать)
+	MaxAttempts     int           `mapstructure:"max_attempts"`
+	LockoutDuration time.Duration `mapstructure:"lockout_duration"`
+	// Задержка после неудачной попытки: delay после первой, затем вдвое больше после каждой следующей, но не больше max_delay
+	Delay    time.Duration `mapstructure:"delay"`
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+	// Не больше user_limit попыток под одним логином и ip_limit попыток с одного адреса за window (0 — без ограничения)
+	UserLimit int           `mapstructure:"user_limit"`
+	IPLimit   int           `mapstructure:"ip_limit"`
+	Window    time.Duration `mapstructure:"window"`
+}
+
+// Сроки хранения данных
+type RetentionConfig struct {
+	// Сколько хранятся мягко удалённые записи, прежде чем их можно удалить окончательно (purge)
+	SoftDeleted time.Duration `mapstructure:"soft_deleted"`
+}
+
+// Кадровые действия с будущей датой
+type AssignmentsConfig struct {
+	// Как часто сервер применяет вступившие в силу действия (0 — не применять, только командой apply-assignments)
+	ApplyInterval time.Duration `mapstructure:"apply_interval"`
+}
+
+// Видимость полей сотрудника по ролям (имена полей — json-имена из model.RestrictedEmployeeFields).
+// Пользователю с несколькими ролями поле видно так, как в самой открытой из них;
+// если ни одна из его ролей не описана в roles, действует default.
+type FieldsConfig struct {
+	Default FieldPolicy            `mapstructure:"default"`
+	Roles   map[string]FieldPolicy `mapstructure:"roles"`
+}
+
+// Поля, скрытые от роли и показываемые ей маскированными; остальные видны полностью
+type FieldPolicy struct {
+	Hidden []string `mapstructure:"hidden"`
+	Masked []string `mapstructure:"masked"` // Только email и phonenumber
+}
+
+// Минимальная длина секрета для HS256
+const minSecretLength = 32
+
+// Виды хранилища
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+)
+
+// Addr — адрес, на котором слушает сервер
+func (s ServerConfig) Addr() string {
+	return net.JoinHostPort(s.Host, s.Port)
+}
+
+// DSN — строка подключения к базе данных
+func (d DBConfig) DSN(timeZone string) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
+		d.Host, d.Port, d.User, d.Password, d.DBName, d.SSLMode, timeZone,
+	)
+}
+
+// Key — секрет для подписи токенов
+func (j JWTConfig) Key() []byte {
+	return []byte(j.Secret)
+}
+
+// Policy — задержки и блокировка после неудачных попыток входа
+func (l LoginConfig) Policy() model.LoginPolicy {
+	return model.LoginPolicy{MaxAttempts: l.MaxAttempts, LockoutDuration: l.LockoutDuration, Delay: l.Delay, MaxDelay: l.MaxDelay}
+}
+
+// Значения по умолчанию. Ключи без значения по умолчанию (секреты) перечислены с пустой строкой,
+// чтобы viper знал о них и подхватывал соответствующие переменные окружения.
+var defaults = map[string]interface{}{
+	"server.host":          "localhost",
+	"server.port":          "8080",
+	"server.read_timeout":  "10s",
+	"server.write_timeout": "10s",
+	"server.idle_timeout":  "60s",
+
+	"db.driver":   DriverPostgres,
+	"db.host":     "localhost",
+	"db.port":     "5432",
+	"db.user":     "",
+	"db.password": "",
+	"db.dbname":   "",
+	"db.sslmode":  "disable",
+
+	"db.auto_migrate": false,
+
+	"jwt.secret":      "",
+	"jwt.access_ttl":  "10m",
+	"jwt.refresh_ttl": "720h",
+
+	"login.max_attempts":     5,
+	"login.lockout_duration": "15m",
+	"login.delay":            "1s",
+	"login.max_delay":        "30s",
+	"login.user_limit":       20,
+	"login.ip_limit":         100,
+	"login.window":           "15m",
+
+	"retention.soft_deleted": "2160h",
+
+	"assignments.apply_interval": "1h",
+
+	"fields.default.hidden": []string{"hiredate", "probation_end_date", "termination_date", "status", "photourl", "notes"},
+	"fields.default.masked": []string{"phonenumber"},
+	"fields.roles": map[string]interface{}{
+		"admin":           map[string]interface{}{"hidden": []string{}},
+		"hr_manager":      map[string]interface{}{"hidden": []string{}},
+		"auditor":         map[string]interface{}{"hidden": []string{"notes"}},
+		"department_head": map[string]interface{}{"hidden": []string{"notes"}},
+	},
+
+	"timezone": "Asia/Dushanbe",
+}
+
+// Флаги командной строки и ключи конфигурации, к которым они привязаны
+var flagKeys = map[string]string{
+	"host":        "server.host",
+	"port":        "server.port",
+	"db-driver":   "db.driver",
+	"db-host":     "db.host",
+	"db-port":     "db.port",
+	"db-user":     "db.user",
+	"db-name":     "db.dbname",
+	"db-sslmode":  "db.sslmode",
+	"jwt-ttl":     "jwt.access_ttl",
+	"refresh-ttl": "jwt.refresh_ttl",
+	"retention":   "retention.soft_deleted",
+	"timezone":    "timezone",
+}
+
+// Load — читает конфигурацию из файла, окружения и аргументов командной строки и проверяет её
+func Load(args []string) (*Config, error) {
+	flags := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	configFile := flags.String("config", "./internal/config/config.yaml", "путь к файлу конфигурации")
+	envFile := flags.String("env-file", ".env", "путь к файлу с переменными окружения")
+	for name, key := range flagKeys {
+		flags.String(name, "", "переопределяет "+key)
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// .env необязателен: в контейнере переменные обычно задаются окружением
+	if err := godotenv.Load(*envFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("ошибка загрузки %s: %v", *envFile, err)
+	}
+
+	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	v.SetConfigFile(*configFile)
+	if err := v.ReadInConfig(); err != nil {
+		// Файл конфигурации по умолчанию может отсутствовать, явно указанный — обязан быть
+		missing := errors.Is(err, fs.ErrNotExist)
+		if !missing || flags.Changed("config") {
+			return nil, fmt.Errorf("ошибка чтения %s: %v", *configFile, err)
+		}
+	}
+
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	for name, key := range flagKeys {
+		if err := v.BindPFlag(key, flags.Lookup(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("ошибка разбора конфигурации: %v", err)
+	}
+	cfg.Args = flags.Args()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate — проверяет конфигурацию и возвращает все найденные ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if !validPort(c.Server.Port) {
+		fail("server.port: некорректный порт %q", c.Server.Port)
+	}
+	if c.Server.ReadTimeout <= 0 {
+		fail("server.read_timeout: должен быть больше нуля")
+	}
+	if c.Server.WriteTimeout <= 0 {
+		fail("server.write_timeout: должен быть больше нуля")
+	}
+	if c.Server.IdleTimeout < 0 {
+		fail("server.idle_timeout: не может быть отрицательным")
+	}
+
+	switch c.DB.Driver {
+	case DriverMemory:
+		// Хранилищу в памяти параметры подключения не нужны
+	case DriverPostgres:
+		if c.DB.Host == "" {
+			fail("db.host: не задан")
+		}
+		if !validPort(c.DB.Port) {
+			fail("db.port: некорректный порт %q", c.DB.Port)
+		}
+		if c.DB.User == "" {
+			fail("db.user: не задан")
+		}
+		if c.DB.DBName == "" {
+			fail("db.dbname: не задано имя базы данных")
+		}
+		switch c.DB.SSLMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			fail("db.sslmode: неизвестный режим %q", c.DB.SSLMode)
+		}
+	default:
+		fail("db.driver: неизвестное хранилище %q (ожидается %s или %s)", c.DB.Driver, DriverPostgres, DriverMemory)
+	}
+
+	if c.JWT.Secret == "" {
+		fail("jwt.secret: не задан (переменная окружения JWT_SECRET)")
+	} else if len(c.JWT.Secret) < minSecretLength {
+		fail("jwt.secret: длина должна быть не меньше %d символов", minSecretLength)
+	}
+	if c.JWT.AccessTTL <= 0 {
+		fail("jwt.access_ttl: должен быть больше нуля")
+	}
+	if c.JWT.RefreshTTL <= c.JWT.AccessTTL {
+		fail("jwt.refresh_ttl: должен быть больше jwt.access_ttl")
+	}
+
+	if c.Login.MaxAttempts < 0 {
+		fail("login.max_attempts: не может быть отрицательным")
+	} else if c.Login.MaxAttempts > 0 && c.Login.LockoutDuration <= 0 {
+		fail("login.lockout_duration: должен быть больше нуля")
+	}
+	if c.Login.Delay < 0 {
+		fail("login.delay: не может быть отрицательным")
+	}
+	if c.Login.MaxDelay < c.Login.Delay {
+		fail("login.max_delay: должен быть не меньше login.delay")
+	}
+	if c.Login.UserLimit < 0 || c.Login.IPLimit < 0 {
+		fail("login.user_limit, login.ip_limit: не могут быть отрицательными")
+	}
+	if (c.Login.UserLimit > 0 || c.Login.IPLimit > 0) && c.Login.Window <= 0 {
+		fail("login.window: должен быть больше нуля")
+	}
+
+	if c.Retention.SoftDeleted <= 0 {
+		fail("retention.soft_deleted: должен быть больше нуля")
+	}
+
+	if c.Assignments.ApplyInterval < 0 {
+		fail("assignments.apply_interval: не может быть отрицательным")
+	}
+
+	c.Fields.validate("fields.default", c.Fields.Default, fail)
+	for role, policy := range c.Fields.Roles {
+		c.Fields.validate("fields.roles."+role, policy, fail)
+	}
+
+	if _, err := time.LoadLocation(c.TimeZone); err != nil {
+		fail("timezone: неизвестный часовой пояс %q", c.TimeZone)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("некорректная конфигурация:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validate — в политике только настраиваемые поля, маскировать можно только email и phonenumber,
+// и ни одно поле не указано дважды
+func (FieldsConfig) validate(key string, p FieldPolicy, fail func(format string, args ...interface{})) {
+	seen := make(map[string]bool)
+	for _, field := range p.Hidden {
+		if !slices.Contains(model.RestrictedEmployeeFields, field) {
+			fail("%s.hidden: поле %q нельзя скрыть (допустимы: %s)", key, field, strings.Join(model.RestrictedEmployeeFields, ", "))
+		}
+		seen[field] = true
+	}
+	for _, field := range p.Masked {
+		if !slices.Contains(model.MaskableEmployeeFields, field) {
+			fail("%s.masked: поле %q нельзя маскировать (допустимы: %s)", key, field, strings.Join(model.MaskableEmployeeFields, ", "))
+		}
+		if seen[field] {
+			fail("%s: поле %q одновременно скрыто и маскировано", key, field)
+		}
+	}
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"go.mod/internal/model"
+	"reflect"
+	"strings"
+)
+
+// Журнал аудита
+
+// Поля, значения которых не попадают в журнал — записывается только факт изменения
+var redactedFields = map[string]bool{"password": true}
+
+const redactedValue = "***"
+
+// auditChanges — изменившиеся поля структуры (имена — по json-тегам, id и version не записываются).
+// before == nil — создание, after == nil — удаление; в этих случаях пустые поля пропускаются.
+func auditChanges(before, after interface{}) map[string]model.FieldChange {
+	var bv, av reflect.Value
+	var t reflect.Type
+	if before != nil {
+		bv = reflect.ValueOf(before)
+		t = bv.Type()
+	}
+	if after != nil {
+		av = reflect.ValueOf(after)
+		t = av.Type()
+	}
+
+	changes := make(map[string]model.FieldChange)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "id" || name == "version" {
+			continue
+		}
+
+		var change model.FieldChange
+		switch {
+		case before == nil:
+			if av.Field(i).IsZero() {
+				continue
+			}
+			change.New = fieldValue(av.Field(i))
+		case after == nil:
+			if bv.Field(i).IsZero() {
+				continue
+			}
+			change.Old = fieldValue(bv.Field(i))
+		default:
+			change.Old, change.New = fieldValue(bv.Field(i)), fieldValue(av.Field(i))
+			if reflect.DeepEqual(change.Old, change.New) {
+				continue
+			}
+		}
+
+		if redactedFields[name] {
+			if change.Old != nil {
+				change.Old = redactedValue
+			}
+			if change.New != nil {
+				change.New = redactedValue
+			}
+		}
+		changes[name] = change
+	}
+	return changes
+}
+
+// fieldValue — значение поля; указатель разыменовывается, nil остаётся nil
+func fieldValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+// insertAudit — запись журнала в той же транзакции, что и само изменение
+func insertAudit(tx *sql.Tx, meta model.AuditMeta, action, entity string, id int64, changes map[string]model.FieldChange) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return dbError("ошибка записи журнала аудита", err)
+	}
+
+	query := `INSERT INTO audit_log (actor, action, entity, entity_id, request_id, changes) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.Exec(query, meta.Actor, action, entity, id, meta.RequestID, data); err != nil {
+		return dbError("ошибка записи журнала аудита", err)
+	}
+	return nil
+}
+
+// Получить страницу журнала аудита (новые записи первыми)
+func (d *Database) ListAudit(q model.AuditQuery) (model.AuditPage, error) {
+	page := model.AuditPage{Items: []model.AuditEntry{}, Limit: q.Limit, Offset: q.Offset}
+
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if q.Entity != "" {
+		where = append(where, "entity = "+arg(q.Entity))
+	}
+	if q.EntityID != 0 {
+		where = append(where, "entity_id = "+arg(q.EntityID))
+	}
+	if q.Actor != "" {
+		where = append(where, "actor = "+arg(q.Actor))
+	}
+	if q.Action != "" {
+		where = append(where, "action = "+arg(q.Action))
+	}
+	if q.RequestID != "" {
+		where = append(where, "request_id = "+arg(q.RequestID))
+	}
+	if !q.From.IsZero() {
+		where = append(where, "occurred_at >= "+arg(q.From))
+	}
+	if !q.To.IsZero() {
+		where = append(where, "occurred_at < "+arg(q.To))
+	}
+	if q.Scope.Limited {
+		where = append(where, "(entity <> "+arg(model.AuditEmployee)+" OR entity_id IN (SELECT e.id FROM employees e WHERE "+
+			"e.department_id IN ("+fmt.Sprintf(departmentSubtree, arg(q.Scope.DepartmentID))+")))")
+	}
+
+	countQuery := `SELECT count(*) FROM audit_log` + whereClause(where)
+	if err := d.Connection.QueryRow(countQuery, args...).Scan(&page.Total); err != nil {
+		return page, dbError("ошибка подсчёта записей журнала", err)
+	}
+
+	query := `SELECT id, occurred_at, actor, action, entity, entity_id, request_id, changes FROM audit_log` +
+		whereClause(where) + ` ORDER BY id DESC LIMIT ` + arg(q.Limit) + ` OFFSET ` + arg(q.Offset)
+
+	rows, err := d.Connection.Query(query, args...)
+	if err != nil {
+		return page, dbError("ошибка получения журнала аудита", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.AuditEntry
+		var changes []byte
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.OccurredAt,
+			&entry.Actor,
+			&entry.Action,
+			&entry.Entity,
+			&entry.EntityId,
+			&entry.RequestId,
+			&changes,
+		); err != nil {
+			return page, dbError("ошибка чтения журнала аудита", err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return page, dbError("ошибка чтения журнала аудита", err)
+		}
+		page.Items = append(page.Items, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return page, dbError("ошибка чтения журнала аудита", err)
+	}
+	return page, nil
+}
@@ -1,7 +1,14 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"net"
+	"strings"
 )
 
 // Структура Database будет хранить подключение к базе
@@ -16,6 +23,102 @@ func NewDatabase(db *sql.DB) *Database {
 	}
 }
 
+// isUniqueViolation — ошибка Postgres 23505 (unique_violation)
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// isForeignKeyViolation — ошибка Postgres 23503 (foreign_key_violation)
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// queryError — ошибка запроса к базе. В тексте только пояснение и вид ошибки, без подробностей
+// драйвера (SQL, имён ограничений), поэтому его можно показывать клиенту; исходная ошибка — в Cause.
+type queryError struct {
+	message string
+	kind    error
+	cause   error
+}
+
+func (e *queryError) Error() string {
+	return e.message + ": " + e.kind.Error()
+}
+
+func (e *queryError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
+
+// Cause — исходная ошибка драйвера (для журнала)
+func (e *queryError) Cause() error {
+	return e.cause
+}
+
+// dbError — ошибка запроса с пояснением; известные ошибки Postgres и драйвера помечаются
+// типизированной ошибкой хранилища (ErrNotFound, ErrDuplicate, ErrReferenced, ErrTimeout, ErrUnavailable)
+func dbError(message string, err error) error {
+	var kind error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		kind = ErrNotFound
+	case isUniqueViolation(err):
+		kind = ErrDuplicate
+	case isForeignKeyViolation(err):
+		kind = ErrReferenced
+	case isTimeout(err):
+		kind = ErrTimeout
+	case isConnectionError(err):
+		kind = ErrUnavailable
+	default:
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return &queryError{message: message, kind: kind, cause: err}
+}
+
+// isTimeout — истёк контекст или таймаут сети, либо Postgres отменил запрос
+// (57014 query_canceled — statement_timeout, 55P03 lock_not_available — lock_timeout)
+func isTimeout(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "57014" || pqErr.Code == "55P03"
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// isConnectionError — соединение с базой потеряно или не установлено
+// (класс 08 — connection_exception, 57P01..57P03 — сервер останавливается или не готов)
+func isConnectionError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Class() == "08" || pqErr.Code == "57P01" || pqErr.Code == "57P02" || pqErr.Code == "57P03"
+	}
+	var netErr *net.OpError
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr)
+}
+
+// checkAffected — возвращает ErrNotFound, если запрос не затронул ни одной строки
+func checkAffected(res sql.Result, entity string, id int64) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return dbError("ошибка выполнения запроса", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s с id %d не найден: %w", entity, id, ErrNotFound)
+	}
+	return nil
+}
+
+// checkVersion — ErrVersionMismatch, если версия записи отличается от ожидаемой (0 — без проверки)
+func checkVersion(entity string, id int64, expected, actual int) error {
+	if expected != 0 && expected != actual {
+		return fmt.Errorf("%s с id %d уже изменён (версия %d, ожидалась %d): %w", entity, id, actual, expected, ErrVersionMismatch)
+	}
+	return nil
+}
+
 //import (
 //	"database/sql"
 //)
@@ -29,3 +132,20 @@ func NewDatabase(db *sql.DB) *Database {
 //		connection: db,
 //	}
 //}
+
+// whereClause — собирает условия через AND
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// lowerAll — значения в нижнем регистре (для сравнения без учёта регистра)
+func lowerAll(values []string) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = strings.ToLower(v)
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"go.mod/internal/model"
+)
+
+// Отделы
+
+const departmentColumns = `id, name, parent_id, head_id`
+
+func scanDepartment(row rowScanner) (model.Department, error) {
+	var department model.Department
+	err := row.Scan(
+		&department.Id,
+		&department.Name,
+		&department.ParentId,
+		&department.HeadId,
+	)
+	return department, err
+}
+
+// Получить отдел по ID
+func (d *Database) GetDepartmentByID(id int64) (model.Department, error) {
+	query := `SELECT ` + departmentColumns + ` FROM departments WHERE id=$1`
+
+	department, err := scanDepartment(d.Connection.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return department, fmt.Errorf("отдел с id %d не найден: %w", id, ErrNotFound)
+		}
+		return department, dbError("ошибка получения отдела", err)
+	}
+	return department, nil
+}
+
+// Получить отдел по названию (без учёта регистра)
+func (d *Database) GetDepartmentByName(name string) (model.Department, error) {
+	query := `SELECT ` + departmentColumns + ` FROM departments WHERE lower(name)=lower($1)`
+
+	department, err := scanDepartment(d.Connection.QueryRow(query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return department, fmt.Errorf("отдел %s не найден: %w", name, ErrNotFound)
+		}
+		return department, dbError("ошибка получения отдела", err)
+	}
+	return department, nil
+}
+
+// Получить все отделы
+func (d *Database) GetAllDepartments() ([]model.Department, error) {
+	rows, err := d.Connection.Query(`SELECT ` + departmentColumns + ` FROM departments ORDER BY name, id`)
+	if err != nil {
+		return nil, dbError("ошибка получения отделов", err)
+	}
+	defer rows.Close()
+
+	departments := []model.Department{}
+	for rows.Next() {
+		department, err := scanDepartment(rows)
+		if err != nil {
+			return nil, dbError("ошибка чтения отделов", err)
+		}
+		departments = append(departments, department)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения отделов", err)
+	}
+	return departments, nil
+}
+
+// Создать отдел, возвращает его ID
+func (d *Database) CreateDepartment(department model.Department) (int64, error) {
+	query := `INSERT INTO departments (name, parent_id, head_id) VALUES ($1, $2, $3) RETURNING id`
+
+	var id int64
+	err := d.Connection.QueryRow(query, department.Name, department.ParentId, department.HeadId).Scan(&id)
+	if err != nil {
+		return 0, departmentWriteError(department, err)
+	}
+	return id, nil
+}
+
+// Обновить отдел. Вышестоящим нельзя сделать сам отдел или любой из его подотделов.
+func (d *Database) UpdateDepartment(id int64, department model.Department) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// Два параллельных переноса могут вместе образовать цикл, поэтому изменения дерева выполняются по очереди
+	if _, err := tx.Exec(`LOCK TABLE departments IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return dbError("ошибка блокировки отделов", err)
+	}
+
+	if department.ParentId != nil {
+		query := `WITH RECURSIVE subtree(id) AS (
+				SELECT $1::int
+				UNION
+				SELECT c.id FROM departments c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)`
+
+		var cycle bool
+		if err := tx.QueryRow(query, id, *department.ParentId).Scan(&cycle); err != nil {
+			return dbError("ошибка проверки иерархии отделов", err)
+		}
+		if cycle {
+			return fmt.Errorf("отдел %d не может входить в собственный подотдел: %w", id, ErrCycle)
+		}
+	}
+
+	query := `UPDATE departments SET name=$1, parent_id=$2, head_id=$3 WHERE id=$4`
+	res, err := tx.Exec(query, department.Name, department.ParentId, department.HeadId, id)
+	if err != nil {
+		return departmentWriteError(department, err)
+	}
+	if err := checkAffected(res, "отдел", id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError("ошибка сохранения отдела", err)
+	}
+	return nil
+}
+
+// Удалить отдел (только без подотделов и сотрудников)
+func (d *Database) DeleteDepartment(id int64) error {
+	res, err := d.Connection.Exec(`DELETE FROM departments WHERE id=$1`, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("в отделе %d есть подотделы или сотрудники: %w", id, ErrReferenced)
+		}
+		return dbError("ошибка удаления отдела", err)
+	}
+	return checkAffected(res, "отдел", id)
+}
+
+func departmentWriteError(department model.Department, err error) error {
+	switch {
+	case isUniqueViolation(err):
+		return fmt.Errorf("отдел %s уже существует: %w", department.Name, ErrDuplicate)
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("вышестоящий отдел или руководитель не найден: %w", ErrReferenced)
+	}
+	return dbError("ошибка сохранения отдела", err)
+}
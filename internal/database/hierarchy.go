@@ -0,0 +1,102 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"go.mod/internal/model"
+)
+
+// Подчинённость сотрудников
+
+// Ключ pg_advisory_xact_lock, под которым выполняются смены руководителей
+const managerLockKey int64 = 7_245_104_232
+
+// Порядок сотрудников в списках подчинённых
+const employeeNameOrder = ` ORDER BY e.lastname, e.firstname, e.id`
+
+// checkManagerCycle — ErrCycle, если managerID — сам сотрудник или один из его подчинённых
+func checkManagerCycle(tx *sql.Tx, id, managerID int64) error {
+	// Две параллельные смены руководителя могут вместе образовать цикл, поэтому выполняются по очереди
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, managerLockKey); err != nil {
+		return dbError("ошибка блокировки подчинённости", err)
+	}
+
+	// Поднимаемся от нового руководителя к вершине и ищем в цепочке самого сотрудника
+	query := `WITH RECURSIVE chain(id) AS (
+			SELECT $2::int
+			UNION
+			SELECT e.manager_id FROM employees e JOIN chain c ON e.id = c.id WHERE e.manager_id IS NOT NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM chain WHERE id = $1)`
+
+	var cycle bool
+	if err := tx.QueryRow(query, id, managerID).Scan(&cycle); err != nil {
+		return dbError("ошибка проверки подчинённости", err)
+	}
+	if cycle {
+		return fmt.Errorf("сотрудник %d не может подчиняться сам себе или своему подчинённому: %w", id, ErrCycle)
+	}
+	return nil
+}
+
+// Все списки ограничены областью доступа scope: сотрудники вне её в них не попадают.
+
+// Получить всех неудалённых сотрудников (для построения оргструктуры)
+func (d *Database) GetAllEmployees(scope model.Scope) ([]model.Employee, error) {
+	filter, args := scopeFilter(scope, nil)
+	return d.queryEmployees(`SELECT `+employeeColumns+employeeTables+` WHERE `+employeeActive+filter+employeeNameOrder, "сотрудников", args...)
+}
+
+// Непосредственные подчинённые
+func (d *Database) GetDirectReports(managerID int64, scope model.Scope) ([]model.Employee, error) {
+	filter, args := scopeFilter(scope, []interface{}{managerID})
+	query := `SELECT ` + employeeColumns + employeeTables + ` WHERE e.manager_id = $1 AND ` + employeeActive + filter + employeeNameOrder
+	return d.queryEmployees(query, "подчинённых", args...)
+}
+
+// Цепочка руководителей: от непосредственного до верхнего. Цепочка обрывается на удалённом руководителе.
+func (d *Database) GetManagerChain(id int64, scope model.Scope) ([]model.Employee, error) {
+	filter, args := scopeFilter(scope, []interface{}{id})
+	query := `WITH RECURSIVE chain(id, depth) AS (
+			SELECT manager_id, 1 FROM employees WHERE id = $1 AND manager_id IS NOT NULL
+			UNION ALL
+			SELECT m.manager_id, c.depth + 1 FROM employees m JOIN chain c ON m.id = c.id
+			WHERE m.manager_id IS NOT NULL AND m.deleted_at IS NULL
+		)
+		SELECT ` + employeeColumns + employeeTables + ` JOIN chain c ON c.id = e.id WHERE ` + employeeActive + filter + ` ORDER BY c.depth`
+	return d.queryEmployees(query, "руководителей", args...)
+}
+
+// Все неудалённые подчинённые сотрудника на всех уровнях (без него самого)
+func (d *Database) GetSubordinates(id int64, scope model.Scope) ([]model.Employee, error) {
+	filter, args := scopeFilter(scope, []interface{}{id})
+	query := `WITH RECURSIVE subordinates(id) AS (
+			SELECT id FROM employees WHERE manager_id = $1 AND deleted_at IS NULL
+			UNION
+			SELECT e.id FROM employees e JOIN subordinates s ON e.manager_id = s.id WHERE e.deleted_at IS NULL
+		)
+		SELECT ` + employeeColumns + employeeTables + ` JOIN subordinates s ON s.id = e.id WHERE ` + employeeActive + filter + employeeNameOrder
+	return d.queryEmployees(query, "подчинённых", args...)
+}
+
+// queryEmployees — выполняет запрос, возвращающий колонки employeeColumns
+func (d *Database) queryEmployees(query, what string, args ...interface{}) ([]model.Employee, error) {
+	rows, err := d.Connection.Query(query, args...)
+	if err != nil {
+		return nil, dbError("ошибка получения "+what, err)
+	}
+	defer rows.Close()
+
+	employees := []model.Employee{}
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			return nil, dbError("ошибка чтения "+what, err)
+		}
+		employees = append(employees, employee)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения "+what, err)
+	}
+	return employees, nil
+}
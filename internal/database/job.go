@@ -0,0 +1,324 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go.mod/internal/model"
+	"log"
+)
+
+// Кадровая история сотрудника
+
+// jobChange — запись кадровой истории, которой соответствует изменение сотрудника (created — запись только что создана);
+// false, если должность, отдел и занятость не изменились. Дата вступления в силу — дата приёма или увольнения,
+// а если она не указана, и для переводов — сегодня.
+func jobChange(before, after model.Employee, created bool) (model.JobAssignment, bool) {
+	employedBefore := !created && model.Employed(before.Status)
+	a := model.JobAssignment{
+		EmployeeId:    int64(after.Id),
+		Position:      after.Position,
+		DepartmentId:  after.DepartmentId,
+		EffectiveDate: model.Today(),
+	}
+
+	switch {
+	case !employedBefore && model.Employed(after.Status):
+		a.Action = model.JobHire
+		if before.Status == model.StatusTerminated {
+			a.Action = model.JobRehire
+		}
+		if !after.HireDate.IsZero() {
+			a.EffectiveDate = after.HireDate
+		}
+	case employedBefore && after.Status == model.StatusTerminated:
+		a.Action = model.JobTermination
+		if !after.TerminationDate.IsZero() {
+			a.EffectiveDate = after.TerminationDate
+		}
+	case employedBefore && model.Employed(after.Status) &&
+		(before.Position != after.Position || !sameDepartment(before.DepartmentId, after.DepartmentId)):
+		a.Action = model.JobTransfer
+	default:
+		return a, false
+	}
+	return a, true
+}
+
+// applyAssignment — запись сотрудника после вступления в силу кадрового действия. Статус меняется,
+// только если это разрешено model.StatusTransitions.
+func applyAssignment(e model.Employee, a model.JobAssignment) model.Employee {
+	e.Position = a.Position
+	e.DepartmentId = a.DepartmentId
+
+	status := model.StatusActive
+	switch a.Action {
+	case model.JobHire:
+		e.HireDate = a.EffectiveDate
+	case model.JobRehire:
+		e.HireDate = a.EffectiveDate
+		e.ProbationEndDate, e.TerminationDate = model.Date{}, model.Date{}
+	case model.JobTermination:
+		e.TerminationDate = a.EffectiveDate
+		status = model.StatusTerminated
+	default:
+		status = e.Status
+	}
+	if model.CanTransition(e.Status, status) {
+		e.Status = status
+	}
+	return e
+}
+
+// employeeAsOf — должность, отдел и занятость сотрудника по кадровому действию, действовавшему на дату
+func employeeAsOf(e model.Employee, a model.JobAssignment) model.Employee {
+	e.Position = a.Position
+	e.DepartmentId = a.DepartmentId
+	e.Department = a.Department
+	switch {
+	case a.Action == model.JobTermination:
+		e.Status = model.StatusTerminated
+	case !model.Employed(e.Status):
+		e.Status = model.StatusActive
+	}
+	return e
+}
+
+// sameDepartment — два необязательных ID отдела равны
+func sameDepartment(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Сотрудники с должностью, отделом и занятостью на дату (%[1]s — параметр с датой).
+// Сотрудники без кадровых действий до этой даты (ещё не принятые) в выборку не попадают.
+const employeeTablesAsOf = ` FROM (
+		SELECT x.id, x.lastname, x.firstname, x.middlename, j.position, j.department_id, x.manager_id, x.email, x.phonenumber,
+			x.hiredate, x.probation_end_date, x.termination_date,
+			CASE WHEN j.action = 'termination' THEN 'terminated'
+			     WHEN x.status IN ('candidate', 'terminated') THEN 'active'
+			     ELSE x.status END AS status,
+			x.photourl, x.notes, x.version, x.deleted_at, x.deleted_by, x.department_id AS current_department_id
+		FROM employees x
+		JOIN LATERAL (
+			SELECT a.action, a.position, a.department_id FROM job_assignments a
+			WHERE a.employee_id = x.id AND a.effective_date <= %[1]s
+			ORDER BY a.effective_date DESC, a.id DESC LIMIT 1
+		) j ON true
+	) e LEFT JOIN departments d ON d.id = e.department_id`
+
+// Текущий отдел сотрудника в employeeTablesAsOf: по нему, а не по отделу на дату, проверяется область доступа
+const asOfScopeColumn = "e.current_department_id"
+
+// Колонки кадрового действия в порядке полей model.JobAssignment
+const jobColumns = `a.id, a.employee_id, a.action, a.effective_date, a.position, a.department_id, COALESCE(d.name, ''),
+	a.reason, a.created_at, a.created_by, a.applied_at`
+
+const jobTables = ` FROM job_assignments a LEFT JOIN departments d ON d.id = a.department_id`
+
+func scanJobAssignment(row rowScanner) (model.JobAssignment, error) {
+	var a model.JobAssignment
+	err := row.Scan(&a.Id, &a.EmployeeId, &a.Action, &a.EffectiveDate, &a.Position, &a.DepartmentId, &a.Department,
+		&a.Reason, &a.CreatedAt, &a.CreatedBy, &a.AppliedAt)
+	return a, err
+}
+
+// insertJobAssignment — кадровое действие в той же транзакции, что и изменение сотрудника;
+// applied — изменение уже перенесено в запись сотрудника
+func insertJobAssignment(tx *sql.Tx, a model.JobAssignment, applied bool, meta model.AuditMeta) (int64, error) {
+	query := `INSERT INTO job_assignments (employee_id, action, effective_date, position, department_id, reason, created_by, applied_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, CASE WHEN $8 THEN now() END)
+			  RETURNING id`
+	var id int64
+	err := tx.QueryRow(query, a.EmployeeId, a.Action, a.EffectiveDate, a.Position, a.DepartmentId, meta.Reason, meta.Actor, applied).Scan(&id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, fmt.Errorf("отдел не найден: %w", ErrReferenced)
+		}
+		return 0, dbError("ошибка записи кадровой истории", err)
+	}
+	return id, nil
+}
+
+// recordJobChange — запись кадровой истории для изменения сотрудника, если оно её затрагивает
+func recordJobChange(tx *sql.Tx, meta model.AuditMeta, before, after model.Employee, created bool) error {
+	a, ok := jobChange(before, after, created)
+	if !ok {
+		return nil
+	}
+	_, err := insertJobAssignment(tx, a, true, meta)
+	return err
+}
+
+// Сотрудник с должностью, отделом и занятостью на дату; ErrNotFound, если на эту дату он ещё не был принят
+// или сейчас не входит в область доступа scope
+func (d *Database) GetEmployeeAsOf(id int64, date model.Date, scope model.Scope) (model.Employee, error) {
+	filter, args := scopeFilterOn(asOfScopeColumn, scope, []interface{}{id, date})
+	query := `SELECT ` + employeeColumns + fmt.Sprintf(employeeTablesAsOf, "$2") + ` WHERE e.id=$1 AND ` + employeeActive + filter
+	employee, err := scanEmployee(d.Connection.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return employee, fmt.Errorf("сотрудник с id %d на %s не найден: %w", id, date, ErrNotFound)
+		}
+		return employee, dbError("ошибка получения сотрудника", err)
+	}
+	return employee, nil
+}
+
+// Кадровая история сотрудника по дате вступления в силу, включая будущие изменения;
+// ErrNotFound, если сотрудник не входит в область доступа scope
+func (d *Database) GetJobHistory(id int64, scope model.Scope) ([]model.JobAssignment, error) {
+	var exists bool
+	filter, args := scopeFilter(scope, []interface{}{id})
+	if err := d.Connection.QueryRow(`SELECT EXISTS (SELECT 1 FROM employees e WHERE e.id=$1`+filter+`)`, args...).Scan(&exists); err != nil {
+		return nil, dbError("ошибка получения сотрудника", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+
+	rows, err := d.Connection.Query(`SELECT `+jobColumns+jobTables+` WHERE a.employee_id=$1 ORDER BY a.effective_date, a.id`, id)
+	if err != nil {
+		return nil, dbError("ошибка получения кадровой истории", err)
+	}
+	defer rows.Close()
+
+	history := []model.JobAssignment{}
+	for rows.Next() {
+		a, err := scanJobAssignment(rows)
+		if err != nil {
+			return nil, dbError("ошибка чтения кадровой истории", err)
+		}
+		history = append(history, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения кадровой истории", err)
+	}
+	return history, nil
+}
+
+// Добавить кадровое действие; если оно вступает в силу не позже today, запись сотрудника меняется сразу
+func (d *Database) CreateJobAssignment(a model.JobAssignment, today model.Date, meta model.AuditMeta) (model.JobAssignment, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.JobAssignment{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем сотрудника, чтобы действие не разошлось с параллельным изменением его записи
+	if _, err := getEmployeeForUpdate(tx, a.EmployeeId, false); err != nil {
+		return model.JobAssignment{}, err
+	}
+	if a.Id, err = insertJobAssignment(tx, a, false, meta); err != nil {
+		return model.JobAssignment{}, err
+	}
+	a.Reason, a.CreatedBy = meta.Reason, meta.Actor
+	if !a.EffectiveDate.After(today) {
+		if err := applyJobAssignment(tx, a, meta); err != nil {
+			return model.JobAssignment{}, err
+		}
+	}
+
+	created, err := scanJobAssignment(tx.QueryRow(`SELECT `+jobColumns+jobTables+` WHERE a.id=$1`, a.Id))
+	if err != nil {
+		return model.JobAssignment{}, dbError("ошибка получения кадрового действия", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return model.JobAssignment{}, dbError("ошибка сохранения кадрового действия", err)
+	}
+	return created, nil
+}
+
+// ApplyJobAssignments — переносит в записи сотрудников кадровые действия, вступившие в силу к today.
+// Возвращает количество применённых действий.
+func (d *Database) ApplyJobAssignments(today model.Date, meta model.AuditMeta) (int, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED: параллельный запуск на другом экземпляре пропускает уже взятые действия
+	query := `SELECT ` + jobColumns + jobTables + ` WHERE a.applied_at IS NULL AND a.effective_date <= $1
+			  ORDER BY a.effective_date, a.id FOR UPDATE OF a SKIP LOCKED`
+	rows, err := tx.Query(query, today)
+	if err != nil {
+		return 0, dbError("ошибка получения кадровых действий", err)
+	}
+	var due []model.JobAssignment
+	for rows.Next() {
+		a, err := scanJobAssignment(rows)
+		if err != nil {
+			rows.Close()
+			return 0, dbError("ошибка чтения кадровых действий", err)
+		}
+		due = append(due, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, dbError("ошибка чтения кадровых действий", err)
+	}
+
+	for _, a := range due {
+		if err := applyJobAssignment(tx, a, meta); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError("ошибка применения кадровых действий", err)
+	}
+	return len(due), nil
+}
+
+// applyJobAssignment — переносит действие в запись сотрудника и отмечает его применённым.
+// Запись не меняется, если сотрудник удалён или уже действует более позднее действие
+// (действие задним числом только дополняет историю).
+func applyJobAssignment(tx *sql.Tx, a model.JobAssignment, meta model.AuditMeta) error {
+	if _, err := tx.Exec(`UPDATE job_assignments SET applied_at = now() WHERE id=$1`, a.Id); err != nil {
+		return dbError("ошибка применения кадрового действия", err)
+	}
+
+	var superseded bool
+	err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM job_assignments
+		WHERE employee_id=$1 AND applied_at IS NOT NULL AND id <> $2
+		  AND (effective_date > $3 OR (effective_date = $3 AND id > $2)))`, a.EmployeeId, a.Id, a.EffectiveDate).Scan(&superseded)
+	if err != nil {
+		return dbError("ошибка применения кадрового действия", err)
+	}
+	if superseded {
+		return nil
+	}
+
+	before, err := getEmployeeForUpdate(tx, a.EmployeeId, false)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("кадровое действие %d: сотрудник %d удалён, запись не изменена", a.Id, a.EmployeeId)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	changed := applyAssignment(before, a)
+	query := `UPDATE employees SET position=$1, department_id=$2, status=$3, hiredate=$4, probation_end_date=$5, termination_date=$6,
+			  version=version+1 WHERE id=$7`
+	if _, err := tx.Exec(query, changed.Position, changed.DepartmentId, changed.Status, changed.HireDate,
+		changed.ProbationEndDate, changed.TerminationDate, a.EmployeeId); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("отдел не найден: %w", ErrReferenced)
+		}
+		return dbError("ошибка применения кадрового действия", err)
+	}
+
+	after, err := getEmployeeForUpdate(tx, a.EmployeeId, false)
+	if err != nil {
+		return err
+	}
+	meta.Reason = a.Reason
+	if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditEmployee, a.EmployeeId, auditChanges(before, after)); err != nil {
+		return err
+	}
+	return insertStatusChange(tx, meta, a.EmployeeId, before.Status, after.Status)
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go.mod/internal/model"
+	"strconv"
+)
+
+// Курсор не удалось разобрать или он выдан для другого порядка сортировки
+var ErrInvalidCursor = errors.New("некорректный курсор")
+
+// Содержимое курсора: порядок сортировки и значения полей последней записи страницы
+type cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// withIDTiebreak — добавляет id в конец сортировки, чтобы порядок был однозначным
+func withIDTiebreak(sort []model.SortField) []model.SortField {
+	for _, f := range sort {
+		if f.Field == "id" {
+			return sort
+		}
+	}
+	result := make([]model.SortField, len(sort), len(sort)+1)
+	copy(result, sort)
+	return append(result, model.SortField{Field: "id"})
+}
+
+// encodeCursor — курсор, указывающий на позицию сразу после записи employee
+func encodeCursor(sort []model.SortField, employee model.Employee) string {
+	c := cursor{Sort: model.SortKey(sort), Values: make([]string, len(sort))}
+	for i, f := range sort {
+		c.Values[i] = employeeSortValue(employee, f.Field)
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor — значения полей сортировки из курсора
+func decodeCursor(value string, sort []model.SortField) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != model.SortKey(sort) || len(c.Values) != len(sort) {
+		return nil, ErrInvalidCursor
+	}
+	for i, f := range sort {
+		if f.Field == "id" {
+			if _, err := strconv.ParseInt(c.Values[i], 10, 64); err != nil {
+				return nil, ErrInvalidCursor
+			}
+		}
+	}
+	return c.Values, nil
+}
+
+// employeeSortValue — значение поля сортировки в том виде, в каком оно сравнивается в запросе
+func employeeSortValue(e model.Employee, field string) string {
+	switch field {
+	case "id":
+		return strconv.Itoa(e.Id)
+	case "lastname":
+		return e.LastName
+	case "firstname":
+		return e.FirstName
+	case "middlename":
+		return e.MiddleName
+	case "position":
+		return e.Position
+	case "department":
+		return e.Department
+	case "email":
+		return e.Email
+	case "hiredate":
+		return e.HireDate.String()
+	case "status":
+		return e.Status
+	}
+	return ""
+}
@@ -0,0 +1,858 @@
+package database
+
+import (
+	"cmp"
+	"fmt"
+	"go.mod/internal/model"
+	"go.mod/pkg/password"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryDatabase — хранилище в памяти с тем же поведением, что и Postgres (для тестов и локальных демо)
+type MemoryDatabase struct {
+	mu sync.RWMutex
+
+	employees        map[int64]model.Employee
+	departments      map[int64]model.Department
+	users            map[int64]model.User
+	roles            map[int64]model.Role
+	refreshTokens    map[string]*model.RefreshToken // ключ — хеш токена
+	revokedTokens    map[string]time.Time           // jti -> срок действия
+	audit            []model.AuditEntry             // Журнал аудита в порядке добавления
+	statusHistory    []model.StatusChange           // История статусов сотрудников в порядке добавления
+	jobAssignments   []model.JobAssignment          // Кадровая история в порядке добавления
+	nextEmployeeID   int64
+	nextDepartmentID int64
+	nextUserID       int64
+	nextRoleID       int64
+	nextTokenID      int64
+	nextJobID        int64
+}
+
+// Конструктор пустого хранилища в памяти (со встроенными ролями)
+func NewMemoryDatabase() *MemoryDatabase {
+	m := &MemoryDatabase{
+		employees:     make(map[int64]model.Employee),
+		departments:   make(map[int64]model.Department),
+		users:         make(map[int64]model.User),
+		roles:         make(map[int64]model.Role),
+		refreshTokens: make(map[string]*model.RefreshToken),
+		revokedTokens: make(map[string]time.Time),
+	}
+	m.seedRoles()
+	return m
+}
+
+// Сотрудник
+
+func (m *MemoryDatabase) GetEmployeeByID(id int64, includeDeleted bool, scope model.Scope) (model.Employee, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	employee, ok := m.employees[id]
+	if !ok || (employee.DeletedAt != nil && !includeDeleted) || !m.inScope(employee, scope) {
+		return employee, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+	return m.withDepartment(employee), nil
+}
+
+// inScope — сотрудник в области доступа scope, как условие scopeFilter (вызывается под блокировкой)
+func (m *MemoryDatabase) inScope(e model.Employee, scope model.Scope) bool {
+	if !scope.Limited {
+		return true
+	}
+	return e.DepartmentId != nil && m.departmentSubtree(scope.DepartmentID)[int64(*e.DepartmentId)]
+}
+
+// withDepartment — подставляет название отдела, как LEFT JOIN departments (вызывается под блокировкой)
+func (m *MemoryDatabase) withDepartment(e model.Employee) model.Employee {
+	e.Department = ""
+	if e.DepartmentId != nil {
+		e.Department = m.departments[int64(*e.DepartmentId)].Name
+	}
+	return e
+}
+
+func (m *MemoryDatabase) ListEmployees(q model.EmployeeQuery) (model.EmployeePage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sortFields := withIDTiebreak(q.Sort)
+	page := model.EmployeePage{Items: []model.Employee{}, Limit: q.Limit, Offset: q.Offset}
+
+	// Фильтры
+	var subtree map[int64]bool
+	if q.DepartmentID != 0 {
+		subtree = map[int64]bool{q.DepartmentID: true}
+		if q.Subdepartments {
+			subtree = m.departmentSubtree(q.DepartmentID)
+		}
+	}
+
+	var matched []model.Employee
+	for _, e := range m.employees {
+		e = m.withDepartment(e)
+		if e.DeletedAt != nil && !q.IncludeDeleted {
+			continue
+		}
+		if !m.inScope(e, q.Scope) {
+			continue
+		}
+		if !q.AsOf.IsZero() {
+			a, ok := m.assignmentAsOf(int64(e.Id), q.AsOf)
+			if !ok {
+				continue
+			}
+			e = employeeAsOf(e, a)
+		}
+		if len(q.Department) > 0 && !containsFold(q.Department, e.Department) {
+			continue
+		}
+		if subtree != nil && (e.DepartmentId == nil || !subtree[int64(*e.DepartmentId)]) {
+			continue
+		}
+		if len(q.Position) > 0 && !containsFold(q.Position, e.Position) {
+			continue
+		}
+		if len(q.Status) > 0 && !contains(q.Status, e.Status) {
+			continue
+		}
+		if q.HiredFrom != "" && (e.HireDate.IsZero() || e.HireDate.String() < q.HiredFrom) {
+			continue
+		}
+		if q.HiredTo != "" && (e.HireDate.IsZero() || e.HireDate.String() > q.HiredTo) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	page.Total = len(matched)
+
+	sort.Slice(matched, func(i, j int) bool {
+		return compareEmployees(matched[i], matched[j], sortFields) < 0
+	})
+
+	// Позиция курсора или смещение
+	start := q.Offset
+	if q.Cursor != "" {
+		values, err := decodeCursor(q.Cursor, sortFields)
+		if err != nil {
+			return page, err
+		}
+		start = sort.Search(len(matched), func(i int) bool {
+			return compareWithCursor(matched[i], values, sortFields) > 0
+		})
+	}
+	if start > len(matched) {
+		start = len(matched)
+	}
+
+	end := start + q.Limit
+	if end < len(matched) {
+		page.NextCursor = encodeCursor(sortFields, matched[end-1])
+	} else {
+		end = len(matched)
+	}
+	page.Items = append(page.Items, matched[start:end]...)
+	return page, nil
+}
+
+// compareEmployees — сравнение по полям сортировки с учётом направления
+func compareEmployees(a, b model.Employee, fields []model.SortField) int {
+	for _, f := range fields {
+		c := compareSortValues(f.Field, employeeSortValue(a, f.Field), employeeSortValue(b, f.Field))
+		if f.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareWithCursor — положение записи относительно позиции курсора (> 0 — после курсора)
+func compareWithCursor(e model.Employee, values []string, fields []model.SortField) int {
+	for i, f := range fields {
+		c := compareSortValues(f.Field, employeeSortValue(e, f.Field), values[i])
+		if f.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareSortValues(field, a, b string) int {
+	if field == "id" {
+		x, _ := strconv.ParseInt(a, 10, 64)
+		y, _ := strconv.ParseInt(b, 10, 64)
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(a, b)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryDatabase) CreateEmployee(employee model.Employee, meta model.AuditMeta) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.departmentExists(employee.DepartmentId) || !m.employeeExists(employee.ManagerId) {
+		return 0, fmt.Errorf("отдел или руководитель сотрудника не найден: %w", ErrReferenced)
+	}
+
+	m.nextEmployeeID++
+	employee.Department = ""
+	employee.Version = 1
+	employee.DeletedAt, employee.DeletedBy = nil, ""
+	employee.Id = int(m.nextEmployeeID)
+	m.employees[m.nextEmployeeID] = employee
+	m.appendAudit(meta, model.AuditCreate, model.AuditEmployee, m.nextEmployeeID, auditChanges(nil, m.withDepartment(employee)))
+	m.appendStatusChange(meta, m.nextEmployeeID, "", employee.Status)
+	m.appendJobChange(meta, model.Employee{}, employee, true)
+	return m.nextEmployeeID, nil
+}
+
+func (m *MemoryDatabase) UpdateEmployee(id int64, version int, employee model.Employee, scope model.Scope, meta model.AuditMeta) (model.Employee, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.employees[id]
+	if !ok || before.DeletedAt != nil || !m.inScope(before, scope) {
+		return model.Employee{}, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+	if err := checkVersion("сотрудник", id, version, before.Version); err != nil {
+		return model.Employee{}, err
+	}
+	if err := checkStatusTransition(id, before.Status, employee.Status); err != nil {
+		return model.Employee{}, err
+	}
+	if employee.ManagerId != nil && m.managerChain(int64(*employee.ManagerId))[id] {
+		return model.Employee{}, fmt.Errorf("сотрудник %d не может подчиняться сам себе или своему подчинённому: %w", id, ErrCycle)
+	}
+	if !m.departmentExists(employee.DepartmentId) || !m.employeeExists(employee.ManagerId) {
+		return model.Employee{}, fmt.Errorf("отдел или руководитель сотрудника не найден: %w", ErrReferenced)
+	}
+	employee.Id = int(id)
+	employee.Department = ""
+	employee.Version = before.Version + 1
+	employee.DeletedAt, employee.DeletedBy = nil, ""
+	m.employees[id] = employee
+	m.appendAudit(meta, model.AuditUpdate, model.AuditEmployee, id, auditChanges(m.withDepartment(before), m.withDepartment(employee)))
+	m.appendStatusChange(meta, id, before.Status, employee.Status)
+	m.appendJobChange(meta, before, employee, false)
+	return m.withDepartment(employee), nil
+}
+
+func (m *MemoryDatabase) DeleteEmployee(id int64, version int, scope model.Scope, meta model.AuditMeta) error {
+	return m.setEmployeeDeleted(id, version, true, scope, meta)
+}
+
+func (m *MemoryDatabase) RestoreEmployee(id int64, scope model.Scope, meta model.AuditMeta) error {
+	return m.setEmployeeDeleted(id, 0, false, scope, meta)
+}
+
+func (m *MemoryDatabase) setEmployeeDeleted(id int64, version int, deleted bool, scope model.Scope, meta model.AuditMeta) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.employees[id]
+	if !ok || (before.DeletedAt != nil) != !deleted || !m.inScope(before, scope) {
+		return fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+	if err := checkVersion("сотрудник", id, version, before.Version); err != nil {
+		return err
+	}
+
+	after, action := before, model.AuditRestore
+	after.Version++
+	after.DeletedAt, after.DeletedBy = nil, ""
+	if deleted {
+		now := time.Now()
+		after.DeletedAt, after.DeletedBy, action = &now, meta.Actor, model.AuditDelete
+	}
+	m.employees[id] = after
+	m.appendAudit(meta, action, model.AuditEmployee, id, auditChanges(m.withDepartment(before), m.withDepartment(after)))
+	return nil
+}
+
+func (m *MemoryDatabase) PurgeEmployees(before time.Time, meta model.AuditMeta) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	purged := make(map[int64]bool)
+	for id, employee := range m.employees {
+		if employee.DeletedAt != nil && employee.DeletedAt.Before(before) {
+			purged[id] = true
+		}
+	}
+
+	// Ссылки на удаляемых снимаются явно, с записью в журнал (как в clearEmployeeReferences)
+	for reportID, report := range m.employees {
+		if report.ManagerId != nil && purged[int64(*report.ManagerId)] && !purged[reportID] {
+			after := report
+			after.ManagerId = nil
+			after.Version++
+			m.employees[reportID] = after
+			m.appendAudit(meta, model.AuditUpdate, model.AuditEmployee, reportID, auditChanges(m.withDepartment(report), m.withDepartment(after)))
+		}
+	}
+	for depID, department := range m.departments {
+		if department.HeadId != nil && purged[int64(*department.HeadId)] {
+			after := department
+			after.HeadId = nil
+			m.departments[depID] = after
+			m.appendAudit(meta, model.AuditUpdate, model.AuditDepartment, depID, auditChanges(department, after))
+		}
+	}
+
+	for id := range purged {
+		m.appendAudit(meta, model.AuditPurge, model.AuditEmployee, id, auditChanges(m.withDepartment(m.employees[id]), nil))
+		delete(m.employees, id)
+
+		// Как ON DELETE CASCADE для employee_status_history и job_assignments
+		history := m.statusHistory[:0]
+		for _, c := range m.statusHistory {
+			if c.EmployeeId != id {
+				history = append(history, c)
+			}
+		}
+		m.statusHistory = history
+		assignments := m.jobAssignments[:0]
+		for _, a := range m.jobAssignments {
+			if a.EmployeeId != id {
+				assignments = append(assignments, a)
+			}
+		}
+		m.jobAssignments = assignments
+	}
+	return len(purged), nil
+}
+
+// Отделы
+
+func (m *MemoryDatabase) GetDepartmentByID(id int64) (model.Department, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	department, ok := m.departments[id]
+	if !ok {
+		return department, fmt.Errorf("отдел с id %d не найден: %w", id, ErrNotFound)
+	}
+	return department, nil
+}
+
+func (m *MemoryDatabase) GetDepartmentByName(name string) (model.Department, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, department := range m.departments {
+		if strings.EqualFold(department.Name, name) {
+			return department, nil
+		}
+	}
+	return model.Department{}, fmt.Errorf("отдел %s не найден: %w", name, ErrNotFound)
+}
+
+func (m *MemoryDatabase) GetAllDepartments() ([]model.Department, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	departments := make([]model.Department, 0, len(m.departments))
+	for _, department := range m.departments {
+		departments = append(departments, department)
+	}
+	sort.Slice(departments, func(i, j int) bool {
+		if departments[i].Name != departments[j].Name {
+			return departments[i].Name < departments[j].Name
+		}
+		return departments[i].Id < departments[j].Id
+	})
+	return departments, nil
+}
+
+func (m *MemoryDatabase) CreateDepartment(department model.Department) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkDepartment(0, department); err != nil {
+		return 0, err
+	}
+
+	m.nextDepartmentID++
+	department.Id = int(m.nextDepartmentID)
+	m.departments[m.nextDepartmentID] = department
+	return m.nextDepartmentID, nil
+}
+
+func (m *MemoryDatabase) UpdateDepartment(id int64, department model.Department) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.departments[id]; !ok {
+		return fmt.Errorf("отдел с id %d не найден: %w", id, ErrNotFound)
+	}
+	if department.ParentId != nil && m.departmentSubtree(id)[int64(*department.ParentId)] {
+		return fmt.Errorf("отдел %d не может входить в собственный подотдел: %w", id, ErrCycle)
+	}
+	if err := m.checkDepartment(id, department); err != nil {
+		return err
+	}
+
+	department.Id = int(id)
+	m.departments[id] = department
+	return nil
+}
+
+func (m *MemoryDatabase) DeleteDepartment(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.departments[id]; !ok {
+		return fmt.Errorf("отдел с id %d не найден: %w", id, ErrNotFound)
+	}
+
+	// Как ON DELETE RESTRICT для parent_id и employees.department_id
+	for _, department := range m.departments {
+		if department.ParentId != nil && int64(*department.ParentId) == id {
+			return fmt.Errorf("в отделе %d есть подотделы или сотрудники: %w", id, ErrReferenced)
+		}
+	}
+	for _, employee := range m.employees {
+		if employee.DepartmentId != nil && int64(*employee.DepartmentId) == id {
+			return fmt.Errorf("в отделе %d есть подотделы или сотрудники: %w", id, ErrReferenced)
+		}
+	}
+
+	// Как ON DELETE SET NULL для users.department_id
+	for userID, user := range m.users {
+		if user.DepartmentId != nil && int64(*user.DepartmentId) == id {
+			user.DepartmentId = nil
+			m.users[userID] = user
+		}
+	}
+
+	delete(m.departments, id)
+	return nil
+}
+
+// checkDepartment — уникальность названия и внешние ключи (вызывается под блокировкой)
+func (m *MemoryDatabase) checkDepartment(id int64, department model.Department) error {
+	for otherID, other := range m.departments {
+		if otherID != id && strings.EqualFold(other.Name, department.Name) {
+			return fmt.Errorf("отдел %s уже существует: %w", department.Name, ErrDuplicate)
+		}
+	}
+	if !m.departmentExists(department.ParentId) {
+		return fmt.Errorf("вышестоящий отдел или руководитель не найден: %w", ErrReferenced)
+	}
+	if department.HeadId != nil {
+		if _, ok := m.employees[int64(*department.HeadId)]; !ok {
+			return fmt.Errorf("вышестоящий отдел или руководитель не найден: %w", ErrReferenced)
+		}
+	}
+	return nil
+}
+
+// departmentExists — nil (отдел не указан) или существующий отдел
+func (m *MemoryDatabase) departmentExists(id *int) bool {
+	if id == nil {
+		return true
+	}
+	_, ok := m.departments[int64(*id)]
+	return ok
+}
+
+// departmentSubtree — ID отдела и всех его подотделов (вызывается под блокировкой)
+func (m *MemoryDatabase) departmentSubtree(id int64) map[int64]bool {
+	subtree := map[int64]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for depID, department := range m.departments {
+			if department.ParentId != nil && subtree[int64(*department.ParentId)] && !subtree[depID] {
+				subtree[depID] = true
+				changed = true
+			}
+		}
+	}
+	return subtree
+}
+
+// Пользователи
+
+func (m *MemoryDatabase) GetUserByID(id int64, includeDeleted bool) (model.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok || (user.DeletedAt != nil && !includeDeleted) {
+		return user, fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+	}
+	return user, nil
+}
+
+func (m *MemoryDatabase) GetUserByUsername(username string) (model.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, user := range m.users {
+		if user.Username == username && user.DeletedAt == nil {
+			return user, nil
+		}
+	}
+	return model.User{}, fmt.Errorf("пользователь %s не найден: %w", username, ErrNotFound)
+}
+
+func (m *MemoryDatabase) GetAllUsers(includeDeleted bool) ([]model.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := make([]model.User, 0, len(m.users))
+	for _, user := range m.users {
+		if user.DeletedAt == nil || includeDeleted {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Id < users[j].Id })
+	return users, nil
+}
+
+func (m *MemoryDatabase) CreateUser(user model.User, meta model.AuditMeta) (int64, error) {
+	hash, err := password.Hash(user.Password)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка хеширования пароля: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.usernameTaken(user.Username, 0) {
+		return 0, fmt.Errorf("логин %s уже занят: %w", user.Username, ErrDuplicate)
+	}
+	if user.Roles, err = m.userRoles(user.Roles); err != nil {
+		return 0, err
+	}
+	if !m.departmentExists(user.DepartmentId) {
+		return 0, fmt.Errorf("отдел пользователя не найден: %w", ErrReferenced)
+	}
+
+	m.nextUserID++
+	user.Id = int(m.nextUserID)
+	user.Password = hash
+	user.Version = 1
+	user.DeletedAt, user.DeletedBy = nil, ""
+	m.users[m.nextUserID] = user
+	m.appendAudit(meta, model.AuditCreate, model.AuditUser, m.nextUserID, auditChanges(nil, user))
+	return m.nextUserID, nil
+}
+
+func (m *MemoryDatabase) UpdateUser(id int64, version int, user model.User, meta model.AuditMeta) (model.User, error) {
+	var hash string
+	if user.Password != "" {
+		var err error
+		if hash, err = password.Hash(user.Password); err != nil {
+			return model.User{}, fmt.Errorf("ошибка хеширования пароля: %v", err)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.users[id]
+	if !ok || old.DeletedAt != nil {
+		return model.User{}, fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+	}
+	if err := checkVersion("пользователь", id, version, old.Version); err != nil {
+		return model.User{}, err
+	}
+	if m.usernameTaken(user.Username, id) {
+		return model.User{}, fmt.Errorf("логин %s уже занят: %w", user.Username, ErrDuplicate)
+	}
+	roles, err := m.userRoles(user.Roles)
+	if err != nil {
+		return model.User{}, err
+	}
+	user.Roles = roles
+	if !m.departmentExists(user.DepartmentId) {
+		return model.User{}, fmt.Errorf("отдел пользователя не найден: %w", ErrReferenced)
+	}
+
+	// Если пароль не передан, старый пароль сохраняется
+	user.Id = int(id)
+	user.Version = old.Version + 1
+	user.DeletedAt, user.DeletedBy = nil, ""
+	user.Password = old.Password
+	if hash != "" {
+		user.Password = hash
+	}
+	user.FailedAttempts, user.LockedUntil = old.FailedAttempts, old.LockedUntil
+	m.users[id] = user
+	m.appendAudit(meta, model.AuditUpdate, model.AuditUser, id, auditChanges(old, user))
+	return user, nil
+}
+
+func (m *MemoryDatabase) RecordLoginAttempt(id int64, policy model.LoginPolicy) (model.User, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.users[id]
+	if !ok || before.DeletedAt != nil {
+		return model.User{}, false, fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+	}
+	now := time.Now()
+	if before.LockedUntil != nil && now.Before(*before.LockedUntil) {
+		return before, false, nil
+	}
+
+	after := before
+	after.FailedAttempts++
+	if policy.MaxAttempts > 0 && before.FailedAttempts >= policy.MaxAttempts {
+		after.FailedAttempts = 1
+	}
+	until, _ := policy.LockedUntil(after.FailedAttempts, now)
+	after.LockedUntil = &until
+	m.users[id] = after
+	return after, true, nil
+}
+
+func (m *MemoryDatabase) RecordLockout(before, after model.User, meta model.AuditMeta) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.appendAudit(meta, model.AuditLock, model.AuditUser, int64(after.Id), auditChanges(before, after))
+	return nil
+}
+
+func (m *MemoryDatabase) ResetLoginFailures(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[id]; ok && user.DeletedAt == nil {
+		user.FailedAttempts, user.LockedUntil = 0, nil
+		m.users[id] = user
+	}
+	return nil
+}
+
+func (m *MemoryDatabase) UnlockUser(id int64, meta model.AuditMeta) (model.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.users[id]
+	if !ok || before.DeletedAt != nil {
+		return model.User{}, fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+	}
+	after := before
+	after.FailedAttempts, after.LockedUntil = 0, nil
+	m.users[id] = after
+	m.appendAudit(meta, model.AuditUnlock, model.AuditUser, id, auditChanges(before, after))
+	return after, nil
+}
+
+func (m *MemoryDatabase) UpdateUserPassword(id int64, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok || user.DeletedAt != nil {
+		return fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+	}
+	user.Password = hash
+	m.users[id] = user
+	return nil
+}
+
+func (m *MemoryDatabase) DeleteUser(id int64, version int, meta model.AuditMeta) error {
+	return m.setUserDeleted(id, version, true, meta)
+}
+
+func (m *MemoryDatabase) RestoreUser(id int64, meta model.AuditMeta) error {
+	return m.setUserDeleted(id, 0, false, meta)
+}
+
+func (m *MemoryDatabase) setUserDeleted(id int64, version int, deleted bool, meta model.AuditMeta) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.users[id]
+	if !ok || (before.DeletedAt != nil) != !deleted {
+		return fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+	}
+	if err := checkVersion("пользователь", id, version, before.Version); err != nil {
+		return err
+	}
+	// Как частичный уникальный индекс users_username_active_key
+	if !deleted && m.usernameTaken(before.Username, id) {
+		return fmt.Errorf("логин %s уже занят: %w", before.Username, ErrDuplicate)
+	}
+
+	after, action := before, model.AuditRestore
+	after.Version++
+	after.DeletedAt, after.DeletedBy = nil, ""
+	if deleted {
+		now := time.Now()
+		after.DeletedAt, after.DeletedBy, action = &now, meta.Actor, model.AuditDelete
+	}
+	m.users[id] = after
+	m.appendAudit(meta, action, model.AuditUser, id, auditChanges(before, after))
+	return nil
+}
+
+func (m *MemoryDatabase) PurgeUsers(before time.Time, meta model.AuditMeta) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	purged := 0
+	for id, user := range m.users {
+		if user.DeletedAt == nil || !user.DeletedAt.Before(before) {
+			continue
+		}
+		m.appendAudit(meta, model.AuditPurge, model.AuditUser, id, auditChanges(user, nil))
+		delete(m.users, id)
+		purged++
+
+		// Как ON DELETE CASCADE в Postgres
+		for hash, token := range m.refreshTokens {
+			if int64(token.UserId) == id {
+				delete(m.refreshTokens, hash)
+			}
+		}
+	}
+	return purged, nil
+}
+
+// usernameTaken — логин занят действующим пользователем (удалённые не учитываются)
+func (m *MemoryDatabase) usernameTaken(username string, exceptID int64) bool {
+	for id, user := range m.users {
+		if id != exceptID && user.Username == username && user.DeletedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Токены
+
+func (m *MemoryDatabase) CreateRefreshToken(token model.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.refreshTokens[token.TokenHash]; ok {
+		return fmt.Errorf("ошибка сохранения refresh-токена: токен уже существует")
+	}
+	m.nextTokenID++
+	token.Id = int(m.nextTokenID)
+	m.refreshTokens[token.TokenHash] = &token
+	return nil
+}
+
+func (m *MemoryDatabase) UseRefreshToken(tokenHash string) (model.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.refreshTokens[tokenHash]
+	if !ok {
+		return model.RefreshToken{}, ErrTokenInvalid
+	}
+
+	now := time.Now()
+	if token.UsedAt != nil || token.RevokedAt != nil {
+		m.revokeTokens(func(t *model.RefreshToken) bool { return t.FamilyId == token.FamilyId })
+		return *token, ErrTokenReused
+	}
+	if !token.ExpiresAt.After(now) {
+		return *token, ErrTokenInvalid
+	}
+
+	token.UsedAt = &now
+	return *token, nil
+}
+
+func (m *MemoryDatabase) GetTokenFamily(tokenHash string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	token, ok := m.refreshTokens[tokenHash]
+	if !ok {
+		return "", ErrTokenInvalid
+	}
+	return token.FamilyId, nil
+}
+
+func (m *MemoryDatabase) RevokeTokenFamily(familyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revokeTokens(func(t *model.RefreshToken) bool { return t.FamilyId == familyID })
+	return nil
+}
+
+func (m *MemoryDatabase) RevokeUserTokens(userID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revokeTokens(func(t *model.RefreshToken) bool { return int64(t.UserId) == userID })
+	return nil
+}
+
+// revokeTokens — вызывается под блокировкой
+func (m *MemoryDatabase) revokeTokens(match func(t *model.RefreshToken) bool) {
+	now := time.Now()
+	for _, token := range m.refreshTokens {
+		if !match(token) {
+			continue
+		}
+		if token.AccessExpiresAt.After(now) {
+			m.revokedTokens[token.AccessJti] = token.AccessExpiresAt
+		}
+		if token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+}
+
+func (m *MemoryDatabase) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.revokedTokens[jti] = expiresAt
+
+	// Заодно удаляем записи, срок действия которых уже закончился
+	now := time.Now()
+	for id, exp := range m.revokedTokens {
+		if exp.Before(now) {
+			delete(m.revokedTokens, id)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryDatabase) IsTokenRevoked(jti string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.revokedTokens[jti]
+	return ok, nil
+}
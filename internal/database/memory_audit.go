@@ -0,0 +1,55 @@
+package database
+
+import (
+	"go.mod/internal/model"
+	"time"
+)
+
+// appendAudit — добавляет запись журнала (вызывается под блокировкой вместе с самим изменением)
+func (m *MemoryDatabase) appendAudit(meta model.AuditMeta, action, entity string, id int64, changes map[string]model.FieldChange) {
+	m.audit = append(m.audit, model.AuditEntry{
+		Id:         int64(len(m.audit) + 1),
+		OccurredAt: time.Now(),
+		Actor:      meta.Actor,
+		Action:     action,
+		Entity:     entity,
+		EntityId:   id,
+		RequestId:  meta.RequestID,
+		Changes:    changes,
+	})
+}
+
+func (m *MemoryDatabase) ListAudit(q model.AuditQuery) (model.AuditPage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	page := model.AuditPage{Items: []model.AuditEntry{}, Limit: q.Limit, Offset: q.Offset}
+
+	// Новые записи первыми
+	skipped := 0
+	for i := len(m.audit) - 1; i >= 0; i-- {
+		entry := m.audit[i]
+		if (q.Entity != "" && entry.Entity != q.Entity) ||
+			(q.EntityID != 0 && entry.EntityId != q.EntityID) ||
+			(q.Actor != "" && entry.Actor != q.Actor) ||
+			(q.Action != "" && entry.Action != q.Action) ||
+			(q.RequestID != "" && entry.RequestId != q.RequestID) ||
+			(!q.From.IsZero() && entry.OccurredAt.Before(q.From)) ||
+			(!q.To.IsZero() && !entry.OccurredAt.Before(q.To)) {
+			continue
+		}
+		if entry.Entity == model.AuditEmployee && q.Scope.Limited {
+			if e, ok := m.employees[entry.EntityId]; !ok || !m.inScope(e, q.Scope) {
+				continue
+			}
+		}
+
+		page.Total++
+		if skipped < q.Offset {
+			skipped++
+		} else if len(page.Items) < q.Limit {
+			page.Items = append(page.Items, entry)
+		}
+	}
+	return page, nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"go.mod/internal/model"
+	"sort"
+)
+
+func (m *MemoryDatabase) GetAllEmployees(scope model.Scope) ([]model.Employee, error) {
+	return m.filterEmployees(scope, func(model.Employee) bool { return true }), nil
+}
+
+func (m *MemoryDatabase) GetDirectReports(managerID int64, scope model.Scope) ([]model.Employee, error) {
+	return m.filterEmployees(scope, func(e model.Employee) bool {
+		return e.ManagerId != nil && int64(*e.ManagerId) == managerID
+	}), nil
+}
+
+func (m *MemoryDatabase) GetManagerChain(id int64, scope model.Scope) ([]model.Employee, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	chain := []model.Employee{}
+	employee, ok := m.employees[id]
+	for ok && employee.ManagerId != nil {
+		employee, ok = m.employees[int64(*employee.ManagerId)]
+		ok = ok && employee.DeletedAt == nil
+		if ok && m.inScope(employee, scope) {
+			chain = append(chain, m.withDepartment(employee))
+		}
+	}
+	return chain, nil
+}
+
+func (m *MemoryDatabase) GetSubordinates(id int64, scope model.Scope) ([]model.Employee, error) {
+	m.mu.RLock()
+	subordinates := map[int64]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for reportID, e := range m.employees {
+			if e.ManagerId != nil && subordinates[int64(*e.ManagerId)] && !subordinates[reportID] && e.DeletedAt == nil {
+				subordinates[reportID] = true
+				changed = true
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	return m.filterEmployees(scope, func(e model.Employee) bool {
+		return int64(e.Id) != id && subordinates[int64(e.Id)]
+	}), nil
+}
+
+// filterEmployees — неудалённые сотрудники из области доступа, удовлетворяющие условию, по фамилии и имени
+func (m *MemoryDatabase) filterEmployees(scope model.Scope, match func(e model.Employee) bool) []model.Employee {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	employees := []model.Employee{}
+	for _, e := range m.employees {
+		if e.DeletedAt == nil && m.inScope(e, scope) && match(e) {
+			employees = append(employees, m.withDepartment(e))
+		}
+	}
+	sort.Slice(employees, func(i, j int) bool {
+		a, b := employees[i], employees[j]
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		if a.FirstName != b.FirstName {
+			return a.FirstName < b.FirstName
+		}
+		return a.Id < b.Id
+	})
+	return employees
+}
+
+// managerChain — ID сотрудника и всех его руководителей вверх по цепочке (вызывается под блокировкой)
+func (m *MemoryDatabase) managerChain(id int64) map[int64]bool {
+	chain := map[int64]bool{}
+	for !chain[id] {
+		chain[id] = true
+		employee, ok := m.employees[id]
+		if !ok || employee.ManagerId == nil {
+			break
+		}
+		id = int64(*employee.ManagerId)
+	}
+	return chain
+}
+
+// employeeExists — nil (сотрудник не указан) или существующий сотрудник
+func (m *MemoryDatabase) employeeExists(id *int) bool {
+	if id == nil {
+		return true
+	}
+	_, ok := m.employees[int64(*id)]
+	return ok
+}
@@ -0,0 +1,151 @@
+package database
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+	"log"
+	"sort"
+	"time"
+)
+
+// appendJobChange — запись кадровой истории для изменения сотрудника (вызывается под блокировкой вместе с самим изменением)
+func (m *MemoryDatabase) appendJobChange(meta model.AuditMeta, before, after model.Employee, created bool) {
+	if a, ok := jobChange(before, after, created); ok {
+		now := time.Now()
+		a.AppliedAt = &now
+		m.appendJobAssignment(a, meta)
+	}
+}
+
+func (m *MemoryDatabase) appendJobAssignment(a model.JobAssignment, meta model.AuditMeta) model.JobAssignment {
+	m.nextJobID++
+	a.Id = m.nextJobID
+	a.Department = ""
+	a.Reason = meta.Reason
+	a.CreatedAt = time.Now()
+	a.CreatedBy = meta.Actor
+	m.jobAssignments = append(m.jobAssignments, a)
+	return a
+}
+
+// withJobDepartment — кадровое действие с названием отдела
+func (m *MemoryDatabase) withJobDepartment(a model.JobAssignment) model.JobAssignment {
+	if a.DepartmentId != nil {
+		a.Department = m.departments[int64(*a.DepartmentId)].Name
+	}
+	return a
+}
+
+// assignmentAsOf — кадровое действие сотрудника, действовавшее на дату
+func (m *MemoryDatabase) assignmentAsOf(id int64, date model.Date) (model.JobAssignment, bool) {
+	var found model.JobAssignment
+	ok := false
+	for _, a := range m.jobAssignments {
+		if a.EmployeeId != id || a.EffectiveDate.After(date) {
+			continue
+		}
+		if !ok || a.EffectiveDate.After(found.EffectiveDate) || (a.EffectiveDate == found.EffectiveDate && a.Id > found.Id) {
+			found, ok = a, true
+		}
+	}
+	return m.withJobDepartment(found), ok
+}
+
+func (m *MemoryDatabase) GetEmployeeAsOf(id int64, date model.Date, scope model.Scope) (model.Employee, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	e, ok := m.employees[id]
+	a, employed := m.assignmentAsOf(id, date)
+	if !ok || e.DeletedAt != nil || !employed || !m.inScope(e, scope) {
+		return model.Employee{}, fmt.Errorf("сотрудник с id %d на %s не найден: %w", id, date, ErrNotFound)
+	}
+	return employeeAsOf(e, a), nil
+}
+
+func (m *MemoryDatabase) GetJobHistory(id int64, scope model.Scope) ([]model.JobAssignment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e, ok := m.employees[id]; !ok || !m.inScope(e, scope) {
+		return nil, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+
+	history := []model.JobAssignment{}
+	for _, a := range m.jobAssignments {
+		if a.EmployeeId == id {
+			history = append(history, m.withJobDepartment(a))
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].EffectiveDate.Before(history[j].EffectiveDate) })
+	return history, nil
+}
+
+func (m *MemoryDatabase) CreateJobAssignment(a model.JobAssignment, today model.Date, meta model.AuditMeta) (model.JobAssignment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.employees[a.EmployeeId]; !ok || e.DeletedAt != nil {
+		return model.JobAssignment{}, fmt.Errorf("сотрудник с id %d не найден: %w", a.EmployeeId, ErrNotFound)
+	}
+	if !m.departmentExists(a.DepartmentId) {
+		return model.JobAssignment{}, fmt.Errorf("отдел не найден: %w", ErrReferenced)
+	}
+
+	a.AppliedAt = nil
+	a = m.appendJobAssignment(a, meta)
+	if !a.EffectiveDate.After(today) {
+		a = m.applyJobAssignment(len(m.jobAssignments)-1, meta)
+	}
+	return m.withJobDepartment(a), nil
+}
+
+func (m *MemoryDatabase) ApplyJobAssignments(today model.Date, meta model.AuditMeta) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []int
+	for i, a := range m.jobAssignments {
+		if a.AppliedAt == nil && !a.EffectiveDate.After(today) {
+			due = append(due, i)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return m.jobAssignments[due[i]].EffectiveDate.Before(m.jobAssignments[due[j]].EffectiveDate)
+	})
+
+	for _, i := range due {
+		m.applyJobAssignment(i, meta)
+	}
+	return len(due), nil
+}
+
+// applyJobAssignment — как applyJobAssignment для Postgres: действие отмечается применённым,
+// запись сотрудника меняется, если он не удалён и более позднее действие ещё не действует
+func (m *MemoryDatabase) applyJobAssignment(i int, meta model.AuditMeta) model.JobAssignment {
+	now := time.Now()
+	m.jobAssignments[i].AppliedAt = &now
+	a := m.jobAssignments[i]
+
+	for _, other := range m.jobAssignments {
+		if other.EmployeeId == a.EmployeeId && other.AppliedAt != nil && other.Id != a.Id &&
+			(other.EffectiveDate.After(a.EffectiveDate) || (other.EffectiveDate == a.EffectiveDate && other.Id > a.Id)) {
+			return a
+		}
+	}
+
+	before, ok := m.employees[a.EmployeeId]
+	if !ok || before.DeletedAt != nil {
+		log.Printf("кадровое действие %d: сотрудник %d удалён, запись не изменена", a.Id, a.EmployeeId)
+		return a
+	}
+
+	after := applyAssignment(before, a)
+	after.Version++
+	m.employees[a.EmployeeId] = after
+
+	meta.Reason = a.Reason
+	m.appendAudit(meta, model.AuditUpdate, model.AuditEmployee, a.EmployeeId, auditChanges(m.withDepartment(before), m.withDepartment(after)))
+	m.appendStatusChange(meta, a.EmployeeId, before.Status, after.Status)
+	return a
+}
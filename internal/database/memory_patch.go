@@ -0,0 +1,43 @@
+package database
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+)
+
+func (m *MemoryDatabase) PatchEmployee(id int64, version int, employee model.Employee, fields []string, scope model.Scope, meta model.AuditMeta) (model.Employee, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.employees[id]
+	if !ok || before.DeletedAt != nil || !m.inScope(before, scope) {
+		return model.Employee{}, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+	if err := checkVersion("сотрудник", id, version, before.Version); err != nil {
+		return model.Employee{}, err
+	}
+	if len(fields) == 0 {
+		return m.withDepartment(before), nil
+	}
+
+	after := before
+	if err := copyEmployeeFields(&after, employee, fields); err != nil {
+		return model.Employee{}, err
+	}
+	if err := checkStatusTransition(id, before.Status, after.Status); err != nil {
+		return model.Employee{}, err
+	}
+	if after.ManagerId != nil && m.managerChain(int64(*after.ManagerId))[id] {
+		return model.Employee{}, fmt.Errorf("сотрудник %d не может подчиняться сам себе или своему подчинённому: %w", id, ErrCycle)
+	}
+	if !m.departmentExists(after.DepartmentId) || !m.employeeExists(after.ManagerId) {
+		return model.Employee{}, fmt.Errorf("отдел или руководитель сотрудника не найден: %w", ErrReferenced)
+	}
+
+	after.Version++
+	m.employees[id] = after
+	m.appendAudit(meta, model.AuditUpdate, model.AuditEmployee, id, auditChanges(m.withDepartment(before), m.withDepartment(after)))
+	m.appendStatusChange(meta, id, before.Status, after.Status)
+	m.appendJobChange(meta, before, after, false)
+	return m.withDepartment(after), nil
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+	"sort"
+)
+
+// seedRoles — встроенные роли, как их создаёт миграция 0013_rbac (вызывается из конструктора)
+func (m *MemoryDatabase) seedRoles() {
+	for _, role := range model.BuiltinRoles {
+		m.nextRoleID++
+		role.Id = int(m.nextRoleID)
+		role.Permissions = append([]string(nil), role.Permissions...)
+		m.roles[m.nextRoleID] = role
+	}
+}
+
+// roleByName — роль по имени (вызывается под блокировкой)
+func (m *MemoryDatabase) roleByName(name string) (model.Role, bool) {
+	for _, role := range m.roles {
+		if role.Name == name {
+			return role, true
+		}
+	}
+	return model.Role{}, false
+}
+
+// userRoles — роли пользователя без повторов и по имени, как их возвращает Postgres;
+// ErrReferenced, если какой-то роли нет (вызывается под блокировкой)
+func (m *MemoryDatabase) userRoles(roles []string) ([]string, error) {
+	unique := uniqueStrings(roles)
+	for _, name := range unique {
+		if _, ok := m.roleByName(name); !ok {
+			return nil, fmt.Errorf("роль %s не найдена: %w", name, ErrReferenced)
+		}
+	}
+	sort.Strings(unique)
+	return unique, nil
+}
+
+func (m *MemoryDatabase) GetRoles() ([]model.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	roles := make([]model.Role, 0, len(m.roles))
+	for _, role := range m.roles {
+		roles = append(roles, withAdminPermissions(role))
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Id < roles[j].Id })
+	return roles, nil
+}
+
+func (m *MemoryDatabase) GetRoleByID(id int64) (model.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	role, ok := m.roles[id]
+	if !ok {
+		return model.Role{}, fmt.Errorf("роль с id %d не найдена: %w", id, ErrNotFound)
+	}
+	return withAdminPermissions(role), nil
+}
+
+func (m *MemoryDatabase) CreateRole(role model.Role, meta model.AuditMeta) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.roleByName(role.Name); ok {
+		return 0, fmt.Errorf("роль %s уже существует: %w", role.Name, ErrDuplicate)
+	}
+
+	m.nextRoleID++
+	role.Id = int(m.nextRoleID)
+	role.Builtin = false
+	role.Permissions = uniqueStrings(role.Permissions)
+	sort.Strings(role.Permissions)
+	m.roles[m.nextRoleID] = role
+	m.appendAudit(meta, model.AuditCreate, model.AuditRole, m.nextRoleID, auditChanges(nil, role))
+	return m.nextRoleID, nil
+}
+
+func (m *MemoryDatabase) UpdateRole(id int64, role model.Role, meta model.AuditMeta) (model.Role, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.roles[id]
+	if !ok {
+		return model.Role{}, fmt.Errorf("роль с id %d не найдена: %w", id, ErrNotFound)
+	}
+
+	after := before
+	after.Description = role.Description
+	after.Permissions = uniqueStrings(role.Permissions)
+	sort.Strings(after.Permissions)
+	m.roles[id] = after
+	m.appendAudit(meta, model.AuditUpdate, model.AuditRole, id, auditChanges(withAdminPermissions(before), withAdminPermissions(after)))
+	return withAdminPermissions(after), nil
+}
+
+func (m *MemoryDatabase) DeleteRole(id int64, meta model.AuditMeta) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	role, ok := m.roles[id]
+	if !ok {
+		return fmt.Errorf("роль с id %d не найдена: %w", id, ErrNotFound)
+	}
+	if role.Builtin {
+		return fmt.Errorf("встроенную роль %s нельзя удалить: %w", role.Name, ErrReferenced)
+	}
+	// Как внешний ключ user_roles.role_id (удалённые, но не очищенные пользователи тоже учитываются)
+	for _, user := range m.users {
+		for _, name := range user.Roles {
+			if name == role.Name {
+				return fmt.Errorf("роль %s назначена пользователям: %w", role.Name, ErrReferenced)
+			}
+		}
+	}
+
+	delete(m.roles, id)
+	m.appendAudit(meta, model.AuditDelete, model.AuditRole, id, auditChanges(role, nil))
+	return nil
+}
+
+func (m *MemoryDatabase) SetUserRoles(userID int64, roles []string, meta model.AuditMeta) (model.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	before, ok := m.users[userID]
+	if !ok || before.DeletedAt != nil {
+		return model.User{}, fmt.Errorf("пользователь с id %d не найден: %w", userID, ErrNotFound)
+	}
+	roles, err := m.userRoles(roles)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	after := before
+	after.Roles = roles
+	after.Version++
+	m.users[userID] = after
+	m.appendAudit(meta, model.AuditUpdate, model.AuditUser, userID, auditChanges(before, after))
+	return after, nil
+}
+
+func (m *MemoryDatabase) GetUserPermissions(username string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var permissions []string
+	for _, user := range m.users {
+		if user.Username != username || user.DeletedAt != nil {
+			continue
+		}
+		for _, name := range user.Roles {
+			role, ok := m.roleByName(name)
+			if !ok {
+				continue
+			}
+			if role.Name == model.RoleAdmin {
+				return append([]string(nil), model.Permissions...), nil
+			}
+			permissions = append(permissions, role.Permissions...)
+		}
+	}
+	return uniqueStrings(permissions), nil
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"go.mod/internal/model"
+	"go.mod/pkg/translit"
+	"html"
+	"sort"
+	"strings"
+)
+
+// Порог похожести, как pg_trgm.word_similarity_threshold по умолчанию
+const similarityThreshold = 0.6
+
+// Вес совпадения в зависимости от поля, как setweight в search_vector
+const (
+	weightNames    = 1.0
+	weightPosition = 0.4
+	weightNotes    = 0.2
+)
+
+// SearchEmployees — упрощённый аналог поиска Postgres: префиксы слов, триграммы и транслитерация
+func (m *MemoryDatabase) SearchEmployees(query string, limit int, scope model.Scope, fields model.FieldView) ([]model.EmployeeSearchResult, error) {
+	terms := parseSearch(query)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	results := []model.EmployeeSearchResult{}
+	for _, e := range m.employees {
+		if e.DeletedAt != nil || !m.inScope(e, scope) {
+			continue
+		}
+		e = m.withDepartment(e)
+		// Поля, которые пользователь видит не полностью, в поиске не участвуют
+		searchable := e
+		if !fields.Visible("notes") {
+			searchable.Notes = ""
+		}
+		if !fields.Visible("phonenumber") {
+			searchable.PhoneNumber = ""
+		}
+		if !fields.Visible("email") {
+			searchable.Email = ""
+		}
+		names := strings.Join([]string{e.LastName, e.FirstName, e.MiddleName}, " ")
+
+		rank := textRank(terms.words, searchable)
+		if sim := trigramSimilarity(terms.latin, translit.ToLatin(names)); sim >= similarityThreshold && sim > rank {
+			rank = sim
+		}
+		if terms.digits != "" && strings.Contains(onlyDigits(searchable.PhoneNumber), terms.digits) && rank < 0.5 {
+			rank = 0.5
+		}
+		if terms.raw != "" && strings.Contains(strings.ToLower(searchable.Email), strings.ToLower(terms.raw)) && rank < 0.5 {
+			rank = 0.5
+		}
+		if rank == 0 {
+			continue
+		}
+
+		text := strings.Join([]string{names, e.Position, e.Department, searchable.Notes}, " ")
+		results = append(results, model.EmployeeSearchResult{
+			Employee: e,
+			Rank:     rank,
+			Snippet:  highlight(text, terms.words),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Id < results[j].Id
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// textRank — все слова запроса должны быть префиксами слов сотрудника; вес — по лучшему полю
+func textRank(words []string, e model.Employee) float64 {
+	if len(words) == 0 {
+		return 0
+	}
+	groups := []struct {
+		weight float64
+		tokens []string
+	}{
+		{weightNames, tokenize(e.LastName + " " + e.FirstName + " " + e.MiddleName)},
+		{weightPosition, tokenize(e.Position + " " + e.Department)},
+		{weightNotes, tokenize(e.Notes)},
+	}
+
+	total := 0.0
+	for _, w := range words {
+		best := 0.0
+		for _, g := range groups {
+			if g.weight > best && hasPrefix(g.tokens, w) {
+				best = g.weight
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total / float64(len(words))
+}
+
+// trigramSimilarity — доля триграмм запроса, найденных в тексте
+func trigramSimilarity(query, text string) float64 {
+	q := trigrams(query)
+	if len(q) == 0 {
+		return 0
+	}
+	t := trigrams(text)
+
+	common := 0
+	for tri := range q {
+		if t[tri] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(q))
+}
+
+// trigrams — триграммы слов, дополненных пробелами как в pg_trgm ("  k", " kh", "kho", ...)
+func trigrams(s string) map[string]bool {
+	result := make(map[string]bool)
+	for _, word := range tokenize(s) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])] = true
+		}
+	}
+	return result
+}
+
+// highlight — выделяет слова, начинающиеся с одного из слов запроса; HTML экранируется
+func highlight(text string, words []string) string {
+	parts := strings.Fields(text)
+	for i, part := range parts {
+		marked := false
+		for _, token := range tokenize(part) {
+			if hasPrefix([]string{token}, words...) {
+				marked = true
+				break
+			}
+		}
+		part = html.EscapeString(part)
+		if marked {
+			part = "<mark>" + part + "</mark>"
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, " ")
+}
+
+// hasPrefix — хотя бы один токен начинается хотя бы с одного из префиксов
+func hasPrefix(tokens []string, prefixes ...string) bool {
+	for _, token := range tokens {
+		for _, p := range prefixes {
+			if strings.HasPrefix(token, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
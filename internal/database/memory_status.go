@@ -0,0 +1,41 @@
+package database
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+	"time"
+)
+
+// appendStatusChange — добавляет смену статуса в историю (вызывается под блокировкой вместе с самим изменением)
+func (m *MemoryDatabase) appendStatusChange(meta model.AuditMeta, id int64, from, to string) {
+	if from == to {
+		return
+	}
+	m.statusHistory = append(m.statusHistory, model.StatusChange{
+		Id:         int64(len(m.statusHistory) + 1),
+		EmployeeId: id,
+		From:       from,
+		To:         to,
+		Reason:     meta.Reason,
+		ChangedAt:  time.Now(),
+		ChangedBy:  meta.Actor,
+		RequestId:  meta.RequestID,
+	})
+}
+
+func (m *MemoryDatabase) GetStatusHistory(id int64, scope model.Scope) ([]model.StatusChange, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e, ok := m.employees[id]; !ok || !m.inScope(e, scope) {
+		return nil, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+
+	history := []model.StatusChange{}
+	for _, c := range m.statusHistory {
+		if c.EmployeeId == id {
+			history = append(history, c)
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,15 @@
+-- IF NOT EXISTS: базы, созданные вручную до появления миграций, принимают эту схему как исходную
+CREATE TABLE IF NOT EXISTS employees (
+    id SERIAL PRIMARY KEY,
+    lastname VARCHAR(100) NOT NULL,
+    firstname VARCHAR(100) NOT NULL,
+    middlename VARCHAR(100),
+    position VARCHAR(100),
+    department VARCHAR(100),
+    email VARCHAR(150),
+    phonenumber VARCHAR(50),
+    hiredate DATE,
+    status VARCHAR(50),
+    photourl TEXT,
+    notes TEXT
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(100) NOT NULL UNIQUE,
+    password TEXT NOT NULL, -- хеш argon2id ($argon2id$v=19$m=...,t=...,p=...$соль$хеш)
+    role VARCHAR(50) NOT NULL
+);
+
+-- В старых базах колонка могла быть короче хеша; после миграции: go run ./cmd hash-passwords
+ALTER TABLE users ALTER COLUMN password TYPE TEXT;
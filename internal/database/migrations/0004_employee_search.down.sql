@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS employees_phone_digits_trgm_idx;
+DROP INDEX IF EXISTS employees_email_trgm_idx;
+ALTER TABLE employees DROP COLUMN IF EXISTS search_latin;
+ALTER TABLE employees DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS employee_translit(text);
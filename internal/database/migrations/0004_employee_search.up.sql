@@ -0,0 +1,33 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Транслитерация русских и таджикских букв в латиницу ("Холов" -> "kholov").
+-- Сначала многобуквенные замены, затем побуквенные; ъ и ь (без пары в translate) удаляются.
+-- Таблица должна совпадать с pkg/translit.
+CREATE OR REPLACE FUNCTION employee_translit(value text) RETURNS text
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT translate(
+        replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(
+            lower(value),
+            'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'),
+            'ю', 'yu'), 'я', 'ya'), 'ё', 'yo'), 'ғ', 'gh'),
+        'абвгдезийклмнопрстуфыэӣқӯҳҷъь',
+        'abvgdeziyklmnoprstufyeiquhj'
+    )
+$$;
+
+-- Полнотекстовый поиск: ФИО важнее должности и отдела, заметки — меньше всего
+ALTER TABLE employees ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(lastname, '') || ' ' || coalesce(firstname, '') || ' ' || coalesce(middlename, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(position, '') || ' ' || coalesce(department, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(notes, '')), 'C')
+) STORED;
+
+-- ФИО латиницей для нечёткого поиска с опечатками и в другой раскладке
+ALTER TABLE employees ADD COLUMN search_latin text GENERATED ALWAYS AS (
+    employee_translit(coalesce(lastname, '') || ' ' || coalesce(firstname, '') || ' ' || coalesce(middlename, ''))
+) STORED;
+
+CREATE INDEX employees_search_vector_idx ON employees USING GIN (search_vector);
+CREATE INDEX employees_search_latin_trgm_idx ON employees USING GIN (search_latin gin_trgm_ops);
+CREATE INDEX employees_email_trgm_idx ON employees USING GIN (email gin_trgm_ops);
+CREATE INDEX employees_phone_digits_trgm_idx ON employees USING GIN ((regexp_replace(phonenumber, '\D', '', 'g')) gin_trgm_ops);
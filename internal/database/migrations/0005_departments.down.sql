@@ -0,0 +1,20 @@
+ALTER TABLE employees ADD COLUMN department VARCHAR(100);
+
+UPDATE employees e
+SET department = d.name
+FROM departments d
+WHERE d.id = e.department_id;
+
+DROP INDEX employees_search_vector_idx;
+ALTER TABLE employees DROP COLUMN search_vector;
+
+ALTER TABLE employees ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(lastname, '') || ' ' || coalesce(firstname, '') || ' ' || coalesce(middlename, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(position, '') || ' ' || coalesce(department, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(notes, '')), 'C')
+) STORED;
+
+CREATE INDEX employees_search_vector_idx ON employees USING GIN (search_vector);
+
+ALTER TABLE employees DROP COLUMN department_id;
+DROP TABLE departments;
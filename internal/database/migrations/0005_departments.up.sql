@@ -0,0 +1,43 @@
+-- Отделы: дерево через parent_id, руководитель — сотрудник
+CREATE TABLE departments (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    parent_id INT REFERENCES departments (id) ON DELETE RESTRICT,
+    head_id INT REFERENCES employees (id) ON DELETE SET NULL,
+    CONSTRAINT departments_not_own_parent CHECK (parent_id <> id)
+);
+
+-- Название уникально без учёта регистра: "IT" и "it" — один отдел
+CREATE UNIQUE INDEX departments_name_key ON departments (lower(name));
+CREATE INDEX departments_parent_id_idx ON departments (parent_id);
+
+-- Переносим названия из employees.department. Варианты, отличающиеся только регистром
+-- и пробелами, сливаются в один отдел; синонимы ("IT" и "ИТ") остаются разными отделами —
+-- их нужно объединить вручную (перенести сотрудников и удалить лишний отдел).
+INSERT INTO departments (name)
+SELECT min(btrim(regexp_replace(department, '\s+', ' ', 'g')))
+FROM employees
+WHERE btrim(coalesce(department, '')) <> ''
+GROUP BY lower(btrim(regexp_replace(department, '\s+', ' ', 'g')));
+
+ALTER TABLE employees ADD COLUMN department_id INT REFERENCES departments (id) ON DELETE RESTRICT;
+CREATE INDEX employees_department_id_idx ON employees (department_id);
+
+UPDATE employees e
+SET department_id = d.id
+FROM departments d
+WHERE lower(btrim(regexp_replace(e.department, '\s+', ' ', 'g'))) = lower(d.name);
+
+-- search_vector вычисляется из department, поэтому пересоздаём его без отдела
+-- (название отдела добавляется к вектору в запросе поиска через JOIN)
+DROP INDEX employees_search_vector_idx;
+ALTER TABLE employees DROP COLUMN search_vector;
+ALTER TABLE employees DROP COLUMN department;
+
+ALTER TABLE employees ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(lastname, '') || ' ' || coalesce(firstname, '') || ' ' || coalesce(middlename, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(position, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(notes, '')), 'C')
+) STORED;
+
+CREATE INDEX employees_search_vector_idx ON employees USING GIN (search_vector);
@@ -0,0 +1,4 @@
+-- Непосредственный руководитель сотрудника; циклы в цепочке подчинения проверяются приложением
+ALTER TABLE employees ADD COLUMN manager_id INT REFERENCES employees (id) ON DELETE SET NULL;
+ALTER TABLE employees ADD CONSTRAINT employees_not_own_manager CHECK (manager_id <> id);
+CREATE INDEX employees_manager_id_idx ON employees (manager_id);
@@ -0,0 +1,28 @@
+-- Журнал изменений сотрудников и пользователей; записи только добавляются
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    actor VARCHAR(100) NOT NULL,          -- логин пользователя, выполнившего изменение
+    action VARCHAR(20) NOT NULL,          -- create, update, delete
+    entity VARCHAR(20) NOT NULL,          -- employee, user
+    entity_id INT NOT NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}'   -- {"поле": {"old": ..., "new": ...}}
+);
+
+CREATE INDEX audit_log_entity_idx ON audit_log (entity, entity_id, id);
+CREATE INDEX audit_log_actor_idx ON audit_log (actor, id);
+CREATE INDEX audit_log_occurred_at_idx ON audit_log (occurred_at);
+
+-- Изменять и удалять записи журнала запрещено
+CREATE FUNCTION audit_log_append_only() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log: записи журнала нельзя изменять или удалять';
+END
+$$;
+
+CREATE TRIGGER audit_log_no_update_delete BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,11 @@
+-- Мягко удалённые записи при откате удаляются окончательно
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM employees WHERE deleted_at IS NOT NULL;
+
+DROP INDEX users_username_active_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+
+ALTER TABLE users DROP COLUMN deleted_by;
+ALTER TABLE users DROP COLUMN deleted_at;
+ALTER TABLE employees DROP COLUMN deleted_by;
+ALTER TABLE employees DROP COLUMN deleted_at;
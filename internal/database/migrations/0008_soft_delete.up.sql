@@ -0,0 +1,12 @@
+-- Мягкое удаление: запись помечается, но остаётся в базе до очистки (purge)
+ALTER TABLE employees ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE employees ADD COLUMN deleted_by VARCHAR(100);
+CREATE INDEX employees_deleted_at_idx ON employees (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN deleted_by VARCHAR(100);
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Логин уникален только среди неудалённых пользователей, чтобы его можно было занять снова
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+CREATE UNIQUE INDEX users_username_active_key ON users (username) WHERE deleted_at IS NULL;
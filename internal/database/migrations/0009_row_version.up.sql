@@ -0,0 +1,3 @@
+-- Версия строки для оптимистичной блокировки (ETag / If-Match); увеличивается при каждом изменении
+ALTER TABLE employees ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- Язык сообщений API, выбранный пользователем; пустая строка — по заголовку Accept-Language
+ALTER TABLE users ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- Сброшенные при миграции значения статуса не восстанавливаются (они остаются только в истории, которая удаляется)
+ALTER TABLE employees DROP CONSTRAINT employees_probation_end_date_check;
+ALTER TABLE employees DROP CONSTRAINT employees_termination_date_check;
+ALTER TABLE employees DROP CONSTRAINT employees_status_check;
+DROP TABLE employee_status_history;
+ALTER TABLE employees DROP COLUMN termination_date;
+ALTER TABLE employees DROP COLUMN probation_end_date;
@@ -0,0 +1,36 @@
+-- Даты окончания испытательного срока и увольнения
+ALTER TABLE employees ADD COLUMN probation_end_date DATE;
+ALTER TABLE employees ADD COLUMN termination_date DATE;
+
+-- История смены статусов сотрудника
+CREATE TABLE employee_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id INT NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL DEFAULT '', -- пустой — статус не был задан
+    to_status VARCHAR(50) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    changed_by VARCHAR(100) NOT NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX employee_status_history_employee_idx ON employee_status_history (employee_id, id);
+
+-- Статус становится перечислением. Допустимые значения приводим к нижнему регистру,
+-- остальные сбрасываем, сохранив прежнее значение в истории статусов.
+UPDATE employees SET status = lower(btrim(status))
+WHERE lower(btrim(status)) IN ('candidate', 'onboarding', 'active', 'on_leave', 'suspended', 'terminated');
+
+INSERT INTO employee_status_history (employee_id, from_status, to_status, reason, changed_by)
+SELECT id, status, '', 'статус не входит в перечень допустимых и сброшен при миграции', 'migration'
+FROM employees
+WHERE btrim(coalesce(status, '')) <> ''
+  AND status NOT IN ('candidate', 'onboarding', 'active', 'on_leave', 'suspended', 'terminated');
+
+UPDATE employees SET status = NULL
+WHERE status NOT IN ('candidate', 'onboarding', 'active', 'on_leave', 'suspended', 'terminated');
+
+ALTER TABLE employees ADD CONSTRAINT employees_status_check
+    CHECK (coalesce(status, '') IN ('', 'candidate', 'onboarding', 'active', 'on_leave', 'suspended', 'terminated'));
+ALTER TABLE employees ADD CONSTRAINT employees_termination_date_check CHECK (termination_date >= hiredate);
+ALTER TABLE employees ADD CONSTRAINT employees_probation_end_date_check CHECK (probation_end_date >= hiredate);
@@ -0,0 +1,31 @@
+-- Кадровая история: должность и отдел сотрудника с даты вступления в силу
+CREATE TABLE job_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    employee_id INT NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL
+        CHECK (action IN ('hire', 'transfer', 'promotion', 'demotion', 'termination', 'rehire')),
+    effective_date DATE NOT NULL,
+    position VARCHAR(100) NOT NULL DEFAULT '',
+    department_id INT REFERENCES departments (id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by VARCHAR(100) NOT NULL,
+    applied_at TIMESTAMPTZ -- NULL — изменение ещё не вступило в силу
+);
+
+CREATE INDEX job_assignments_employee_idx ON job_assignments (employee_id, effective_date, id);
+CREATE INDEX job_assignments_pending_idx ON job_assignments (effective_date) WHERE applied_at IS NULL;
+
+-- Начальная история для существующих сотрудников: приём (с даты приёма, а если она неизвестна — с сегодняшнего дня)
+-- и увольнение для уволенных
+INSERT INTO job_assignments (employee_id, action, effective_date, position, department_id, reason, created_by, applied_at)
+SELECT id, 'hire', coalesce(hiredate, current_date), coalesce(position, ''), department_id,
+       'начальная запись при миграции', 'migration', now()
+FROM employees
+WHERE coalesce(status, '') <> 'candidate';
+
+INSERT INTO job_assignments (employee_id, action, effective_date, position, department_id, reason, created_by, applied_at)
+SELECT id, 'termination', coalesce(termination_date, current_date), coalesce(position, ''), department_id,
+       'начальная запись при миграции', 'migration', now()
+FROM employees
+WHERE status = 'terminated';
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN role VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Из нескольких ролей остаётся одна: администратор, если он среди них, иначе первая по имени
+UPDATE users u SET role = coalesce((
+    SELECT r.name FROM user_roles ur JOIN roles r ON r.id = ur.role_id
+    WHERE ur.user_id = u.id
+    ORDER BY r.name <> 'admin', r.name
+    LIMIT 1
+), 'user');
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+
+DROP TABLE user_roles;
+DROP TABLE role_permissions;
+DROP TABLE roles;
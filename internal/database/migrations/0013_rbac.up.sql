@@ -0,0 +1,58 @@
+-- Роли и разрешения: у пользователя может быть несколько ролей,
+-- его разрешения — объединение разрешений всех ролей (см. model.Permissions)
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    builtin BOOLEAN NOT NULL DEFAULT false -- Встроенную роль нельзя удалить
+);
+
+CREATE TABLE role_permissions (
+    role_id INT NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE user_roles (
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role_id INT NOT NULL REFERENCES roles (id), -- Назначенную роль нельзя удалить
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX user_roles_role_idx ON user_roles (role_id);
+
+-- Встроенные роли (те же, что model.BuiltinRoles). Разрешения администратора не хранятся:
+-- у него всегда все разрешения, включая добавленные позже
+INSERT INTO roles (name, description, builtin) VALUES
+    ('admin', 'Администратор: все разрешения', true),
+    ('user', 'Пользователь: просмотр сотрудников и отделов', true),
+    ('hr_manager', 'Кадровик: ведение сотрудников и отделов', true),
+    ('department_head', 'Руководитель отдела: просмотр и изменение сотрудников', true),
+    ('viewer', 'Наблюдатель: только просмотр', true),
+    ('auditor', 'Аудитор: просмотр, истории и журнал аудита', true);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM (VALUES
+    ('user', 'employees:read'), ('user', 'departments:read'),
+    ('hr_manager', 'employees:read'), ('hr_manager', 'employees:create'), ('hr_manager', 'employees:update'),
+    ('hr_manager', 'employees:delete'), ('hr_manager', 'employees:view_deleted'), ('hr_manager', 'employees:history'),
+    ('hr_manager', 'departments:read'), ('hr_manager', 'departments:write'),
+    ('department_head', 'employees:read'), ('department_head', 'employees:update'),
+    ('department_head', 'employees:history'), ('department_head', 'departments:read'),
+    ('viewer', 'employees:read'), ('viewer', 'departments:read'),
+    ('auditor', 'employees:read'), ('auditor', 'employees:view_deleted'), ('auditor', 'employees:history'),
+    ('auditor', 'departments:read'), ('auditor', 'users:read'), ('auditor', 'audit:read')
+) AS p (role, permission)
+JOIN roles r ON r.name = p.role;
+
+-- Роли, которые раньше были записаны у пользователей, но не входят во встроенные, сохраняются без разрешений
+INSERT INTO roles (name, description)
+SELECT DISTINCT role, 'перенесена из users.role при миграции'
+FROM users
+WHERE role <> '' AND role NOT IN (SELECT name FROM roles);
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u JOIN roles r ON r.name = u.role;
+
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission = 'employees:all_departments';
+
+ALTER TABLE users DROP COLUMN department_id;
@@ -0,0 +1,8 @@
+-- Отдел пользователя: пользователь без разрешения employees:all_departments (например, руководитель отдела)
+-- видит и изменяет только сотрудников этого отдела и его подотделов
+ALTER TABLE users ADD COLUMN department_id INT REFERENCES departments (id) ON DELETE SET NULL;
+
+-- Все роли, кроме руководителя отдела, по-прежнему видят всех сотрудников
+-- (у администратора разрешения не хранятся: у него всегда все)
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'employees:all_departments' FROM roles WHERE name NOT IN ('admin', 'department_head');
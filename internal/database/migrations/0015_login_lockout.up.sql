@@ -0,0 +1,4 @@
+-- Защита от подбора пароля: неудачные попытки входа подряд и время, до которого вход закрыт
+-- (задержка после неудачи или блокировка учётной записи)
+ALTER TABLE users ADD COLUMN failed_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMPTZ;
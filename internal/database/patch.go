@@ -0,0 +1,184 @@
+package database
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+	"strings"
+)
+
+// Частичное обновление сотрудника: меняются только перечисленные поля (json-имена из model.EmployeePatchFields)
+
+// Присваивания для UPDATE по полям (%s — параметр со значением)
+var employeePatchColumns = map[string]string{
+	"lastname":           "lastname = %s",
+	"firstname":          "firstname = %s",
+	"middlename":         "middlename = %s",
+	"position":           "position = %s",
+	"department_id":      "department_id = %s",
+	"manager_id":         "manager_id = %s",
+	"email":              "email = %s",
+	"phonenumber":        "phonenumber = %s",
+	"hiredate":           "hiredate = %s",
+	"probation_end_date": "probation_end_date = %s",
+	"termination_date":   "termination_date = %s",
+	"status":             "status = %s",
+	"photourl":           "photourl = %s",
+	"notes":              "notes = %s",
+}
+
+// employeeFieldValue — значение поля сотрудника для параметра запроса
+func employeeFieldValue(e model.Employee, field string) (interface{}, error) {
+	switch field {
+	case "lastname":
+		return e.LastName, nil
+	case "firstname":
+		return e.FirstName, nil
+	case "middlename":
+		return e.MiddleName, nil
+	case "position":
+		return e.Position, nil
+	case "department_id":
+		return e.DepartmentId, nil
+	case "manager_id":
+		return e.ManagerId, nil
+	case "email":
+		return e.Email, nil
+	case "phonenumber":
+		return e.PhoneNumber, nil
+	case "hiredate":
+		return e.HireDate, nil
+	case "probation_end_date":
+		return e.ProbationEndDate, nil
+	case "termination_date":
+		return e.TerminationDate, nil
+	case "status":
+		return e.Status, nil
+	case "photourl":
+		return e.PhotoUrl, nil
+	case "notes":
+		return e.Notes, nil
+	default:
+		return nil, fmt.Errorf("поле %s нельзя изменить частичным обновлением", field)
+	}
+}
+
+// copyEmployeeFields — переносит перечисленные поля из src в dst
+func copyEmployeeFields(dst *model.Employee, src model.Employee, fields []string) error {
+	for _, field := range fields {
+		switch field {
+		case "lastname":
+			dst.LastName = src.LastName
+		case "firstname":
+			dst.FirstName = src.FirstName
+		case "middlename":
+			dst.MiddleName = src.MiddleName
+		case "position":
+			dst.Position = src.Position
+		case "department_id":
+			dst.DepartmentId = src.DepartmentId
+		case "manager_id":
+			dst.ManagerId = src.ManagerId
+		case "email":
+			dst.Email = src.Email
+		case "phonenumber":
+			dst.PhoneNumber = src.PhoneNumber
+		case "hiredate":
+			dst.HireDate = src.HireDate
+		case "probation_end_date":
+			dst.ProbationEndDate = src.ProbationEndDate
+		case "termination_date":
+			dst.TerminationDate = src.TerminationDate
+		case "status":
+			dst.Status = src.Status
+		case "photourl":
+			dst.PhotoUrl = src.PhotoUrl
+		case "notes":
+			dst.Notes = src.Notes
+		default:
+			return fmt.Errorf("поле %s нельзя изменить частичным обновлением", field)
+		}
+	}
+	return nil
+}
+
+// containsField — входит ли поле в список изменяемых
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Частично обновить сотрудника: UPDATE только перечисленных колонок. Возвращает запись после изменения;
+// если менять нечего, запись и её версия остаются прежними.
+func (d *Database) PatchEmployee(id int64, version int, employee model.Employee, fields []string, scope model.Scope, meta model.AuditMeta) (model.Employee, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.Employee{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getScopedEmployeeForUpdate(tx, id, false, scope)
+	if err != nil {
+		return model.Employee{}, err
+	}
+	if err := checkVersion("сотрудник", id, version, before.Version); err != nil {
+		return model.Employee{}, err
+	}
+	if len(fields) == 0 {
+		return before, nil
+	}
+
+	if containsField(fields, "status") {
+		if err := checkStatusTransition(id, before.Status, employee.Status); err != nil {
+			return model.Employee{}, err
+		}
+	}
+	if containsField(fields, "manager_id") && employee.ManagerId != nil {
+		if err := checkManagerCycle(tx, id, int64(*employee.ManagerId)); err != nil {
+			return model.Employee{}, err
+		}
+	}
+
+	set := make([]string, 0, len(fields)+1)
+	args := make([]interface{}, 0, len(fields)+1)
+	for _, field := range fields {
+		value, err := employeeFieldValue(employee, field)
+		if err != nil {
+			return model.Employee{}, err
+		}
+		args = append(args, value)
+		set = append(set, fmt.Sprintf(employeePatchColumns[field], fmt.Sprintf("$%d", len(args))))
+	}
+	set = append(set, "version = version + 1")
+	args = append(args, id)
+
+	query := `UPDATE employees SET ` + strings.Join(set, ", ") + fmt.Sprintf(` WHERE id = $%d`, len(args))
+	if _, err := tx.Exec(query, args...); err != nil {
+		if isForeignKeyViolation(err) {
+			return model.Employee{}, fmt.Errorf("отдел или руководитель сотрудника не найден: %w", ErrReferenced)
+		}
+		return model.Employee{}, dbError("ошибка обновления сотрудника", err)
+	}
+
+	after, err := getEmployeeForUpdate(tx, id, false)
+	if err != nil {
+		return model.Employee{}, err
+	}
+	if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditEmployee, id, auditChanges(before, after)); err != nil {
+		return model.Employee{}, err
+	}
+	if err := insertStatusChange(tx, meta, id, before.Status, after.Status); err != nil {
+		return model.Employee{}, err
+	}
+	if err := recordJobChange(tx, meta, before, after, false); err != nil {
+		return model.Employee{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Employee{}, dbError("ошибка сохранения сотрудника", err)
+	}
+	return after, nil
+}
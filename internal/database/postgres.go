@@ -3,24 +3,17 @@ package database
 import (
 	"database/sql"
 	"fmt"
-	_ "github.com/lib/pq"
-	"github.com/spf13/viper"
+	"github.com/lib/pq"
+	"go.mod/internal/config"
 	"go.mod/internal/model"
+	"go.mod/pkg/password"
 	"log"
-	"os"
+	"strings"
+	"time"
 )
 
-func NewConnectPostgres() *sql.DB {
-	host := viper.GetString("db.host")
-	port := viper.GetString("db.port")
-	user := viper.GetString("db.user")
-	password := os.Getenv("DB_PASSWORD")
-	dbname := viper.GetString("db.dbname")
-
-	dbParams := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=Asia/Dushanbe",
-		host, port, user, password, dbname,
-	)
+func NewConnectPostgres(cfg *config.Config) *sql.DB {
+	dbParams := cfg.DB.DSN(cfg.TimeZone)
 
 	db, err := sql.Open("postgres", dbParams)
 	if err != nil {
@@ -37,11 +30,60 @@ func NewConnectPostgres() *sql.DB {
 
 // Сотрудник
 
-// Получить одного сотрудника по ID
-func (d *Database) GetEmployeeByID(id int64) (model.Employee, error) {
-	query := `SELECT id, lastname, firstname, middlename, position, department, email, phonenumber, hiredate, status, photourl, notes FROM employees WHERE id=$1`
-	row := d.Connection.QueryRow(query, id)
+// Колонки сотрудника в порядке полей model.Employee; NULL в текстовых колонках превращается в пустую строку
+const employeeColumns = `e.id, e.lastname, e.firstname, COALESCE(e.middlename, ''), COALESCE(e.position, ''),
+	COALESCE(d.name, ''), e.department_id, e.manager_id, COALESCE(e.email, ''), COALESCE(e.phonenumber, ''),
+	e.hiredate, e.probation_end_date, e.termination_date, COALESCE(e.status, ''), COALESCE(e.photourl, ''), COALESCE(e.notes, ''),
+	e.version, e.deleted_at, COALESCE(e.deleted_by, '')`
+
+// Условие отбора неудалённых сотрудников
+const employeeActive = `e.deleted_at IS NULL`
+
+// Сотрудники вместе с названием отдела
+const employeeTables = ` FROM employees e LEFT JOIN departments d ON d.id = e.department_id`
+
+// Отдел и все его подотделы (%s — параметр с ID отдела); UNION защищает от зацикливания
+const departmentSubtree = `WITH RECURSIVE subtree(id) AS (
+		SELECT %s::int
+		UNION
+		SELECT c.id FROM departments c JOIN subtree s ON c.parent_id = s.id
+	)
+	SELECT id FROM subtree`
+
+// scopeFilter — ограничение выборки сотрудников e областью доступа пользователя: условие " AND ..."
+// с параметром, добавленным к args; без ограничения — пустая строка
+func scopeFilter(scope model.Scope, args []interface{}) (string, []interface{}) {
+	return scopeFilterOn("e.department_id", scope, args)
+}
+
+// scopeFilterOn — то же, что scopeFilter, по колонке column с ID текущего отдела сотрудника
+func scopeFilterOn(column string, scope model.Scope, args []interface{}) (string, []interface{}) {
+	if !scope.Limited {
+		return "", args
+	}
+	args = append(args, scope.DepartmentID)
+	return " AND " + column + " IN (" + fmt.Sprintf(departmentSubtree, fmt.Sprintf("$%d", len(args))) + ")", args
+}
+
+// Выражения для сортировки и сравнения по курсору (совпадают со значениями из employeeColumns)
+var employeeSortColumns = map[string]string{
+	"id":         "e.id",
+	"lastname":   "e.lastname",
+	"firstname":  "e.firstname",
+	"middlename": "COALESCE(e.middlename, '')",
+	"position":   "COALESCE(e.position, '')",
+	"department": "COALESCE(d.name, '')",
+	"email":      "COALESCE(e.email, '')",
+	"hiredate":   "COALESCE(to_char(e.hiredate, 'YYYY-MM-DD'), '')",
+	"status":     "COALESCE(e.status, '')",
+}
+
+// rowScanner — общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
+func scanEmployee(row rowScanner) (model.Employee, error) {
 	var employee model.Employee
 	err := row.Scan(
 		&employee.Id,
@@ -50,159 +92,557 @@ func (d *Database) GetEmployeeByID(id int64) (model.Employee, error) {
 		&employee.MiddleName,
 		&employee.Position,
 		&employee.Department,
+		&employee.DepartmentId,
+		&employee.ManagerId,
 		&employee.Email,
 		&employee.PhoneNumber,
 		&employee.HireDate,
+		&employee.ProbationEndDate,
+		&employee.TerminationDate,
 		&employee.Status,
 		&employee.PhotoUrl,
 		&employee.Notes,
+		&employee.Version,
+		&employee.DeletedAt,
+		&employee.DeletedBy,
 	)
+	return employee, err
+}
+
+// Получить одного сотрудника по ID; удалённый сотрудник возвращается только при includeDeleted,
+// сотрудник вне области доступа scope не найден
+func (d *Database) GetEmployeeByID(id int64, includeDeleted bool, scope model.Scope) (model.Employee, error) {
+	query := `SELECT ` + employeeColumns + employeeTables + ` WHERE e.id=$1`
+	if !includeDeleted {
+		query += ` AND ` + employeeActive
+	}
+	filter, args := scopeFilter(scope, []interface{}{id})
+	query += filter
+
+	employee, err := scanEmployee(d.Connection.QueryRow(query, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return employee, fmt.Errorf("сотрудник с id %d не найден", id)
+			return employee, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
 		}
-		return employee, fmt.Errorf("ошибка получения сотрудника: %v", err)
+		return employee, dbError("ошибка получения сотрудника", err)
 	}
 
 	return employee, nil
 }
 
-// Получить всех сотрудников
-func (d *Database) GetAllEmployees() ([]model.Employee, error) {
-	query := `SELECT id, lastname, firstname, middlename, position, department, email, phonenumber, hiredate, status, photourl, notes FROM employees`
-	rows, err := d.Connection.Query(query)
+// Получить страницу списка сотрудников с фильтрами и сортировкой
+func (d *Database) ListEmployees(q model.EmployeeQuery) (model.EmployeePage, error) {
+	sort := withIDTiebreak(q.Sort)
+	page := model.EmployeePage{Items: []model.Employee{}, Limit: q.Limit, Offset: q.Offset}
+
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// На дату as_of должность, отдел и занятость берутся из кадровой истории
+	// (область доступа при этом определяется текущим отделом, а не отделом на дату)
+	tables, scopeColumn := employeeTables, "e.department_id"
+	if !q.AsOf.IsZero() {
+		tables, scopeColumn = fmt.Sprintf(employeeTablesAsOf, arg(q.AsOf)), asOfScopeColumn
+	}
+
+	// Фильтры
+	if !q.IncludeDeleted {
+		where = append(where, employeeActive)
+	}
+	if q.Scope.Limited {
+		where = append(where, scopeColumn+" IN ("+fmt.Sprintf(departmentSubtree, arg(q.Scope.DepartmentID))+")")
+	}
+	if len(q.Department) > 0 {
+		where = append(where, "lower(d.name) = ANY("+arg(pq.Array(lowerAll(q.Department)))+")")
+	}
+	if q.DepartmentID != 0 {
+		if q.Subdepartments {
+			where = append(where, "e.department_id IN ("+fmt.Sprintf(departmentSubtree, arg(q.DepartmentID))+")")
+		} else {
+			where = append(where, "e.department_id = "+arg(q.DepartmentID))
+		}
+	}
+	if len(q.Position) > 0 {
+		where = append(where, "lower(e.position) = ANY("+arg(pq.Array(lowerAll(q.Position)))+")")
+	}
+	if len(q.Status) > 0 {
+		where = append(where, "e.status = ANY("+arg(pq.Array(q.Status))+")")
+	}
+	if q.HiredFrom != "" {
+		where = append(where, "e.hiredate >= "+arg(q.HiredFrom)+"::date")
+	}
+	if q.HiredTo != "" {
+		where = append(where, "e.hiredate <= "+arg(q.HiredTo)+"::date")
+	}
+
+	// Общее количество с учётом фильтров, но без учёта курсора
+	countQuery := `SELECT count(*)` + tables + whereClause(where)
+	if err := d.Connection.QueryRow(countQuery, args...).Scan(&page.Total); err != nil {
+		return page, dbError("ошибка подсчёта сотрудников", err)
+	}
+
+	// Позиция курсора: (a > x) OR (a = x AND b < y) OR (a = x AND b = y AND id > z) ...
+	if q.Cursor != "" {
+		values, err := decodeCursor(q.Cursor, sort)
+		if err != nil {
+			return page, err
+		}
+
+		placeholders := make([]string, len(sort))
+		for i, f := range sort {
+			if f.Field == "id" {
+				placeholders[i] = arg(values[i]) + "::bigint"
+			} else {
+				placeholders[i] = arg(values[i])
+			}
+		}
+
+		var or []string
+		for i, f := range sort {
+			var and []string
+			for j := 0; j < i; j++ {
+				and = append(and, employeeSortColumns[sort[j].Field]+" = "+placeholders[j])
+			}
+			op := ">"
+			if f.Desc {
+				op = "<"
+			}
+			and = append(and, employeeSortColumns[f.Field]+" "+op+" "+placeholders[i])
+			or = append(or, "("+strings.Join(and, " AND ")+")")
+		}
+		where = append(where, "("+strings.Join(or, " OR ")+")")
+	}
+
+	order := make([]string, len(sort))
+	for i, f := range sort {
+		order[i] = employeeSortColumns[f.Field]
+		if f.Desc {
+			order[i] += " DESC"
+		}
+	}
+
+	// Берём на одну запись больше, чтобы понять, есть ли следующая страница
+	query := `SELECT ` + employeeColumns + tables + whereClause(where) +
+		` ORDER BY ` + strings.Join(order, ", ") + ` LIMIT ` + arg(q.Limit+1)
+	if q.Cursor == "" {
+		query += ` OFFSET ` + arg(q.Offset)
+	}
+
+	rows, err := d.Connection.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка получения списка сотрудников: %v", err)
+		return page, dbError("ошибка получения списка сотрудников", err)
 	}
 	defer rows.Close()
 
-	var employees []model.Employee
 	for rows.Next() {
-		var emp model.Employee
-		if err := rows.Scan(
-			&emp.Id,
-			&emp.LastName,
-			&emp.FirstName,
-			&emp.MiddleName,
-			&emp.Position,
-			&emp.Department,
-			&emp.Email,
-			&emp.PhoneNumber,
-			&emp.HireDate,
-			&emp.Status,
-			&emp.PhotoUrl,
-			&emp.Notes,
-		); err != nil {
-			return nil, fmt.Errorf("ошибка чтения сотрудников: %v", err)
-		}
-		employees = append(employees, emp)
-	}
-	return employees, nil
-}
-
-// Создать нового сотрудника
-func (d *Database) CreateEmployee(employee model.Employee) error {
-	query := `INSERT INTO employees (lastname, firstname, middlename, position, department, email, phonenumber, hiredate, status, photourl, notes)
-			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
-
-	_, err := d.Connection.Exec(query,
+		emp, err := scanEmployee(rows)
+		if err != nil {
+			return page, dbError("ошибка чтения сотрудников", err)
+		}
+		page.Items = append(page.Items, emp)
+	}
+	if err := rows.Err(); err != nil {
+		return page, dbError("ошибка чтения сотрудников", err)
+	}
+
+	if len(page.Items) > q.Limit {
+		page.Items = page.Items[:q.Limit]
+		page.NextCursor = encodeCursor(sort, page.Items[q.Limit-1])
+	}
+	return page, nil
+}
+
+// getEmployeeForUpdate — сотрудник с блокировкой строки до конца транзакции.
+// deleted выбирает, ищется ли удалённый (для восстановления) или действующий сотрудник.
+func getEmployeeForUpdate(tx *sql.Tx, id int64, deleted bool) (model.Employee, error) {
+	return getScopedEmployeeForUpdate(tx, id, deleted, model.Scope{})
+}
+
+// getScopedEmployeeForUpdate — как getEmployeeForUpdate, но сотрудник вне области доступа scope не найден
+func getScopedEmployeeForUpdate(tx *sql.Tx, id int64, deleted bool, scope model.Scope) (model.Employee, error) {
+	query := `SELECT ` + employeeColumns + employeeTables + ` WHERE e.id=$1 AND e.deleted_at IS NULL`
+	if deleted {
+		query = `SELECT ` + employeeColumns + employeeTables + ` WHERE e.id=$1 AND e.deleted_at IS NOT NULL`
+	}
+	filter, args := scopeFilter(scope, []interface{}{id})
+	query += filter + ` FOR UPDATE OF e`
+
+	employee, err := scanEmployee(tx.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return employee, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+		}
+		return employee, dbError("ошибка получения сотрудника", err)
+	}
+	return employee, nil
+}
+
+// Создать нового сотрудника, возвращает его ID
+func (d *Database) CreateEmployee(employee model.Employee, meta model.AuditMeta) (int64, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO employees (lastname, firstname, middlename, position, department_id, email, phonenumber, hiredate, probation_end_date, termination_date, status, photourl, notes, manager_id)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			  RETURNING id`
+
+	var id int64
+	err = tx.QueryRow(query,
 		employee.LastName,
 		employee.FirstName,
 		employee.MiddleName,
 		employee.Position,
-		employee.Department,
+		employee.DepartmentId,
 		employee.Email,
 		employee.PhoneNumber,
 		employee.HireDate,
+		employee.ProbationEndDate,
+		employee.TerminationDate,
 		employee.Status,
 		employee.PhotoUrl,
 		employee.Notes,
-	)
+		employee.ManagerId,
+	).Scan(&id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, fmt.Errorf("отдел или руководитель сотрудника не найден: %w", ErrReferenced)
+		}
+		return 0, dbError("ошибка создания сотрудника", err)
+	}
+
+	created, err := getEmployeeForUpdate(tx, id, false)
+	if err != nil {
+		return 0, err
+	}
+	if err := insertAudit(tx, meta, model.AuditCreate, model.AuditEmployee, id, auditChanges(nil, created)); err != nil {
+		return 0, err
+	}
+	if err := insertStatusChange(tx, meta, id, "", created.Status); err != nil {
+		return 0, err
+	}
+	if err := recordJobChange(tx, meta, model.Employee{}, created, true); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError("ошибка сохранения сотрудника", err)
+	}
+	return id, nil
+}
+
+// Удалить сотрудника по ID (мягко: запись помечается удалённой и скрывается из выборок)
+func (d *Database) DeleteEmployee(id int64, version int, scope model.Scope, meta model.AuditMeta) error {
+	return d.setEmployeeDeleted(id, version, true, scope, meta)
+}
+
+// Восстановить мягко удалённого сотрудника
+func (d *Database) RestoreEmployee(id int64, scope model.Scope, meta model.AuditMeta) error {
+	return d.setEmployeeDeleted(id, 0, false, scope, meta)
+}
+
+// setEmployeeDeleted — пометка об удалении ставится или снимается вместе с записью в журнал
+func (d *Database) setEmployeeDeleted(id int64, version int, deleted bool, scope model.Scope, meta model.AuditMeta) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// Удалить можно только действующего сотрудника, восстановить — только удалённого
+	before, err := getScopedEmployeeForUpdate(tx, id, !deleted, scope)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion("сотрудник", id, version, before.Version); err != nil {
+		return err
+	}
+
+	query, action := `UPDATE employees SET deleted_at=now(), deleted_by=$2, version=version+1 WHERE id=$1`, model.AuditDelete
+	args := []interface{}{id, meta.Actor}
+	if !deleted {
+		query, action = `UPDATE employees SET deleted_at=NULL, deleted_by=NULL, version=version+1 WHERE id=$1`, model.AuditRestore
+		args = args[:1]
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return dbError("ошибка удаления сотрудника", err)
+	}
+
+	after, err := getEmployeeForUpdate(tx, id, deleted)
 	if err != nil {
-		return fmt.Errorf("ошибка создания сотрудника: %v", err)
+		return err
+	}
+	if err := insertAudit(tx, meta, action, model.AuditEmployee, id, auditChanges(before, after)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError("ошибка сохранения сотрудника", err)
 	}
 	return nil
 }
 
-// Удалить сотрудника по ID
-func (d *Database) DeleteEmployee(id int64) error {
-	query := `DELETE FROM employees WHERE id=$1`
-	_, err := d.Connection.Exec(query, id)
+// PurgeEmployees — окончательно удаляет сотрудников, мягко удалённых раньше before.
+// Содержимое каждой записи сохраняется в журнале аудита. Ссылки на них (руководитель сотрудника,
+// руководитель отдела) снимаются до удаления явно — с записью в журнал и новой версией сотрудника,
+// а не незаметно через ON DELETE SET NULL. Возвращает количество удалённых.
+func (d *Database) PurgeEmployees(before time.Time, meta model.AuditMeta) (int, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + employeeColumns + employeeTables + ` WHERE e.deleted_at < $1 FOR UPDATE OF e`
+	rows, err := tx.Query(query, before)
 	if err != nil {
-		return fmt.Errorf("ошибка удаления сотрудника: %v", err)
+		return 0, dbError("ошибка получения удалённых сотрудников", err)
+	}
+	var purged []model.Employee
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			rows.Close()
+			return 0, dbError("ошибка чтения удалённых сотрудников", err)
+		}
+		purged = append(purged, employee)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, dbError("ошибка чтения удалённых сотрудников", err)
+	}
+
+	ids := make([]int64, 0, len(purged))
+	for _, employee := range purged {
+		ids = append(ids, int64(employee.Id))
+	}
+	if err := clearEmployeeReferences(tx, ids, meta); err != nil {
+		return 0, err
+	}
+
+	for _, employee := range purged {
+		if _, err := tx.Exec(`DELETE FROM employees WHERE id=$1`, employee.Id); err != nil {
+			if isForeignKeyViolation(err) {
+				return 0, fmt.Errorf("на сотрудника %d ссылаются другие записи: %w", employee.Id, ErrReferenced)
+			}
+			return 0, dbError("ошибка удаления сотрудника", err)
+		}
+		if err := insertAudit(tx, meta, model.AuditPurge, model.AuditEmployee, int64(employee.Id), auditChanges(employee, nil)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError("ошибка удаления сотрудников", err)
+	}
+	return len(purged), nil
+}
+
+// clearEmployeeReferences — снимает ссылки на сотрудников ids перед их окончательным удалением:
+// у подчинённых (кроме удаляемых вместе с ними) — руководителя, у отделов — руководителя отдела
+func clearEmployeeReferences(tx *sql.Tx, ids []int64, meta model.AuditMeta) error {
+	query := `SELECT ` + employeeColumns + employeeTables + ` WHERE e.manager_id = ANY($1) AND NOT e.id = ANY($1) FOR UPDATE OF e`
+	rows, err := tx.Query(query, pq.Array(ids))
+	if err != nil {
+		return dbError("ошибка получения подчинённых", err)
+	}
+	var reports []model.Employee
+	for rows.Next() {
+		employee, err := scanEmployee(rows)
+		if err != nil {
+			rows.Close()
+			return dbError("ошибка чтения подчинённых", err)
+		}
+		reports = append(reports, employee)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return dbError("ошибка чтения подчинённых", err)
+	}
+	for _, before := range reports {
+		if _, err := tx.Exec(`UPDATE employees SET manager_id=NULL, version=version+1 WHERE id=$1`, before.Id); err != nil {
+			return dbError("ошибка обновления сотрудника", err)
+		}
+		after := before
+		after.ManagerId = nil
+		after.Version++
+		if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditEmployee, int64(before.Id), auditChanges(before, after)); err != nil {
+			return err
+		}
+	}
+
+	rows, err = tx.Query(`SELECT `+departmentColumns+` FROM departments WHERE head_id = ANY($1) FOR UPDATE`, pq.Array(ids))
+	if err != nil {
+		return dbError("ошибка получения отделов", err)
+	}
+	var departments []model.Department
+	for rows.Next() {
+		department, err := scanDepartment(rows)
+		if err != nil {
+			rows.Close()
+			return dbError("ошибка чтения отделов", err)
+		}
+		departments = append(departments, department)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return dbError("ошибка чтения отделов", err)
+	}
+	for _, before := range departments {
+		if _, err := tx.Exec(`UPDATE departments SET head_id=NULL WHERE id=$1`, before.Id); err != nil {
+			return dbError("ошибка обновления отдела", err)
+		}
+		after := before
+		after.HeadId = nil
+		if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditDepartment, int64(before.Id), auditChanges(before, after)); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// Обновить данные сотрудника
-func (d *Database) UpdateEmployee(id int64, employee model.Employee) error {
+// Обновить данные сотрудника и вернуть новую запись. Руководителем нельзя назначить самого сотрудника
+// или его подчинённого.
+func (d *Database) UpdateEmployee(id int64, version int, employee model.Employee, scope model.Scope, meta model.AuditMeta) (model.Employee, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.Employee{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getScopedEmployeeForUpdate(tx, id, false, scope)
+	if err != nil {
+		return model.Employee{}, err
+	}
+	if err := checkVersion("сотрудник", id, version, before.Version); err != nil {
+		return model.Employee{}, err
+	}
+
+	if err := checkStatusTransition(id, before.Status, employee.Status); err != nil {
+		return model.Employee{}, err
+	}
+	if employee.ManagerId != nil {
+		if err := checkManagerCycle(tx, id, int64(*employee.ManagerId)); err != nil {
+			return model.Employee{}, err
+		}
+	}
+
 	query := `UPDATE employees 
-              SET lastname=$1, firstname=$2, middlename=$3, position=$4, department=$5, email=$6, phonenumber=$7, hiredate=$8, status=$9, photourl=$10, notes=$11
-              WHERE id=$12`
+              SET lastname=$1, firstname=$2, middlename=$3, position=$4, department_id=$5, email=$6, phonenumber=$7, hiredate=$8,
+                  probation_end_date=$9, termination_date=$10, status=$11, photourl=$12, notes=$13, manager_id=$14,
+                  version=version+1
+              WHERE id=$15`
 
-	_, err := d.Connection.Exec(query,
+	_, err = tx.Exec(query,
 		employee.LastName,
 		employee.FirstName,
 		employee.MiddleName,
 		employee.Position,
-		employee.Department,
+		employee.DepartmentId,
 		employee.Email,
 		employee.PhoneNumber,
 		employee.HireDate,
+		employee.ProbationEndDate,
+		employee.TerminationDate,
 		employee.Status,
 		employee.PhotoUrl,
 		employee.Notes,
+		employee.ManagerId,
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("ошибка обновления сотрудника: %v", err)
+		if isForeignKeyViolation(err) {
+			return model.Employee{}, fmt.Errorf("отдел или руководитель сотрудника не найден: %w", ErrReferenced)
+		}
+		return model.Employee{}, dbError("ошибка обновления сотрудника", err)
 	}
-	return nil
+
+	after, err := getEmployeeForUpdate(tx, id, false)
+	if err != nil {
+		return model.Employee{}, err
+	}
+	if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditEmployee, id, auditChanges(before, after)); err != nil {
+		return model.Employee{}, err
+	}
+	if err := insertStatusChange(tx, meta, id, before.Status, after.Status); err != nil {
+		return model.Employee{}, err
+	}
+	if err := recordJobChange(tx, meta, before, after, false); err != nil {
+		return model.Employee{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Employee{}, dbError("ошибка сохранения сотрудника", err)
+	}
+	return after, nil
 }
 
 // Пользователи
 
-// Получить одного пользователя по ID
-func (d *Database) GetUserByID(id int64) (model.User, error) {
-	query := `SELECT id, username, password, role FROM users WHERE id=$1`
+// Колонки пользователя в порядке полей model.User; роли — массив имён из user_roles
+const userColumns = `id, username, password,
+	ARRAY(SELECT r.name FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE ur.user_id = users.id ORDER BY r.name),
+	department_id, locale, version, failed_attempts, locked_until, deleted_at, COALESCE(deleted_by, '')`
 
+func scanUser(row rowScanner) (model.User, error) {
 	var user model.User
-	row := d.Connection.QueryRow(query, id)
 	err := row.Scan(
 		&user.Id,
 		&user.Username,
 		&user.Password,
-		&user.Role,
+		pq.Array(&user.Roles),
+		&user.DepartmentId,
+		&user.Locale,
+		&user.Version,
+		&user.FailedAttempts,
+		&user.LockedUntil,
+		&user.DeletedAt,
+		&user.DeletedBy,
 	)
+	return user, err
+}
+
+// Получить одного пользователя по ID; удалённый пользователь возвращается только при includeDeleted
+func (d *Database) GetUserByID(id int64, includeDeleted bool) (model.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id=$1`
+	if !includeDeleted {
+		query += ` AND deleted_at IS NULL`
+	}
+
+	user, err := scanUser(d.Connection.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return user, fmt.Errorf("пользователь с id %d не найден", id)
+			return user, fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
 		}
-		return user, fmt.Errorf("ошибка при получении пользователя: %v", err)
+		return user, dbError("ошибка при получении пользователя", err)
 	}
 
 	return user, nil
 }
 
-// Получить всех пользователей
-func (d *Database) GetAllUsers() ([]model.User, error) {
-	query := `SELECT id, username, password, role FROM users`
-	rows, err := d.Connection.Query(query)
+// Получить всех пользователей (удалённых — только при includeDeleted)
+func (d *Database) GetAllUsers(includeDeleted bool) ([]model.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users`
+	if !includeDeleted {
+		query += ` WHERE deleted_at IS NULL`
+	}
+	rows, err := d.Connection.Query(query + ` ORDER BY id`)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка выполнения запроса: %v", err)
+		return nil, dbError("ошибка выполнения запроса", err)
 	}
 	defer rows.Close()
 
 	var users []model.User
 	for rows.Next() {
-		var user model.User
-		if err := rows.Scan(
-			&user.Id,
-			&user.Username,
-			&user.Password,
-			&user.Role,
-		); err != nil {
-			return nil, fmt.Errorf("ошибка сканирования пользователя: %v", err)
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, dbError("ошибка сканирования пользователя", err)
 		}
 		users = append(users, user)
 	}
@@ -210,32 +650,384 @@ func (d *Database) GetAllUsers() ([]model.User, error) {
 	return users, nil
 }
 
-// Создать нового пользователя
-func (d *Database) CreateUser(user model.User) error {
-	query := `INSERT INTO users (username, password, role) VALUES ($1, $2, $3)`
-	_, err := d.Connection.Exec(query, user.Username, user.Password, user.Role)
+// Получить действующего пользователя по логину
+func (d *Database) GetUserByUsername(username string) (model.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username=$1 AND deleted_at IS NULL`
+
+	user, err := scanUser(d.Connection.QueryRow(query, username))
 	if err != nil {
-		return fmt.Errorf("ошибка добавления пользователя: %v", err)
+		if err == sql.ErrNoRows {
+			return user, fmt.Errorf("пользователь %s не найден: %w", username, ErrNotFound)
+		}
+		return user, dbError("ошибка при получении пользователя", err)
 	}
-	return nil
+
+	return user, nil
+}
+
+// getUserForUpdate — пользователь с блокировкой строки до конца транзакции.
+// deleted выбирает, ищется ли удалённый (для восстановления) или действующий пользователь.
+func getUserForUpdate(tx *sql.Tx, id int64, deleted bool) (model.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id=$1 AND deleted_at IS NULL FOR UPDATE`
+	if deleted {
+		query = `SELECT ` + userColumns + ` FROM users WHERE id=$1 AND deleted_at IS NOT NULL FOR UPDATE`
+	}
+
+	user, err := scanUser(tx.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, fmt.Errorf("пользователь с id %d не найден: %w", id, ErrNotFound)
+		}
+		return user, dbError("ошибка при получении пользователя", err)
+	}
+	return user, nil
 }
 
-// Удалить пользователя по ID
-func (d *Database) DeleteUser(id int64) error {
-	query := `DELETE FROM users WHERE id=$1`
-	_, err := d.Connection.Exec(query, id)
+// Создать нового пользователя (пароль сохраняется только в виде хеша), возвращает его ID
+func (d *Database) CreateUser(user model.User, meta model.AuditMeta) (int64, error) {
+	hash, err := password.Hash(user.Password)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка хеширования пароля: %v", err)
+	}
+	user.Password = hash
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO users (username, password, department_id, locale) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	var id int64
+	err = tx.QueryRow(query, user.Username, user.Password, user.DepartmentId, user.Locale).Scan(&id)
 	if err != nil {
-		return fmt.Errorf("ошибка удаления пользователя: %v", err)
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("логин %s уже занят: %w", user.Username, ErrDuplicate)
+		}
+		if isForeignKeyViolation(err) {
+			return 0, fmt.Errorf("отдел пользователя не найден: %w", ErrReferenced)
+		}
+		return 0, dbError("ошибка добавления пользователя", err)
+	}
+	if err := setUserRoles(tx, id, user.Roles); err != nil {
+		return 0, err
+	}
+
+	if err := insertAudit(tx, meta, model.AuditCreate, model.AuditUser, id, auditChanges(nil, user)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError("ошибка добавления пользователя", err)
+	}
+	return id, nil
+}
+
+// Удалить пользователя по ID (мягко: вход под ним невозможен, логин освобождается)
+func (d *Database) DeleteUser(id int64, version int, meta model.AuditMeta) error {
+	return d.setUserDeleted(id, version, true, meta)
+}
+
+// Восстановить мягко удалённого пользователя; ErrDuplicate, если его логин уже занят
+func (d *Database) RestoreUser(id int64, meta model.AuditMeta) error {
+	return d.setUserDeleted(id, 0, false, meta)
+}
+
+// setUserDeleted — пометка об удалении ставится или снимается вместе с записью в журнал
+func (d *Database) setUserDeleted(id int64, version int, deleted bool, meta model.AuditMeta) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getUserForUpdate(tx, id, !deleted)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion("пользователь", id, version, before.Version); err != nil {
+		return err
+	}
+
+	query, action := `UPDATE users SET deleted_at=now(), deleted_by=$2, version=version+1 WHERE id=$1`, model.AuditDelete
+	args := []interface{}{id, meta.Actor}
+	if !deleted {
+		query, action = `UPDATE users SET deleted_at=NULL, deleted_by=NULL, version=version+1 WHERE id=$1`, model.AuditRestore
+		args = args[:1]
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("логин %s уже занят: %w", before.Username, ErrDuplicate)
+		}
+		return dbError("ошибка удаления пользователя", err)
+	}
+
+	after, err := getUserForUpdate(tx, id, deleted)
+	if err != nil {
+		return err
+	}
+	if err := insertAudit(tx, meta, action, model.AuditUser, id, auditChanges(before, after)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError("ошибка удаления пользователя", err)
 	}
 	return nil
 }
 
-// Обновить пользователя
-func (d *Database) UpdateUser(id int64, user model.User) error {
-	query := `UPDATE users SET username=$1, password=$2, role=$3 WHERE id=$4`
-	_, err := d.Connection.Exec(query, user.Username, user.Password, user.Role, id)
+// PurgeUsers — окончательно удаляет пользователей, мягко удалённых раньше before.
+// Возвращает количество удалённых.
+func (d *Database) PurgeUsers(before time.Time, meta model.AuditMeta) (int, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT `+userColumns+` FROM users WHERE deleted_at < $1 FOR UPDATE`, before)
 	if err != nil {
-		return fmt.Errorf("ошибка обновления пользователя: %v", err)
+		return 0, dbError("ошибка получения удалённых пользователей", err)
+	}
+	var purged []model.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			rows.Close()
+			return 0, dbError("ошибка чтения удалённых пользователей", err)
+		}
+		purged = append(purged, user)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, dbError("ошибка чтения удалённых пользователей", err)
+	}
+
+	for _, user := range purged {
+		if _, err := tx.Exec(`DELETE FROM users WHERE id=$1`, user.Id); err != nil {
+			return 0, dbError("ошибка удаления пользователя", err)
+		}
+		if err := insertAudit(tx, meta, model.AuditPurge, model.AuditUser, int64(user.Id), auditChanges(user, nil)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError("ошибка удаления пользователей", err)
+	}
+	return len(purged), nil
+}
+
+// Обновить пользователя и вернуть новую запись (если пароль не передан, старый пароль сохраняется)
+func (d *Database) UpdateUser(id int64, version int, user model.User, meta model.AuditMeta) (model.User, error) {
+	var hash string
+	if user.Password != "" {
+		var err error
+		if hash, err = password.Hash(user.Password); err != nil {
+			return model.User{}, fmt.Errorf("ошибка хеширования пароля: %v", err)
+		}
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.User{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getUserForUpdate(tx, id, false)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err := checkVersion("пользователь", id, version, before.Version); err != nil {
+		return model.User{}, err
+	}
+
+	user.Password = before.Password
+	if hash != "" {
+		user.Password = hash
+	}
+
+	query := `UPDATE users SET username=$1, password=$2, department_id=$3, locale=$4, version=version+1 WHERE id=$5`
+	if _, err := tx.Exec(query, user.Username, user.Password, user.DepartmentId, user.Locale, id); err != nil {
+		if isUniqueViolation(err) {
+			return model.User{}, fmt.Errorf("логин %s уже занят: %w", user.Username, ErrDuplicate)
+		}
+		if isForeignKeyViolation(err) {
+			return model.User{}, fmt.Errorf("отдел пользователя не найден: %w", ErrReferenced)
+		}
+		return model.User{}, dbError("ошибка обновления пользователя", err)
+	}
+	if err := setUserRoles(tx, id, user.Roles); err != nil {
+		return model.User{}, err
+	}
+
+	after, err := getUserForUpdate(tx, id, false)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditUser, id, auditChanges(before, after)); err != nil {
+		return model.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.User{}, dbError("ошибка обновления пользователя", err)
+	}
+	return after, nil
+}
+
+// Заменить сохранённый хеш пароля (используется при перехешировании после входа)
+func (d *Database) UpdateUserPassword(id int64, hash string) error {
+	query := `UPDATE users SET password=$1 WHERE id=$2 AND deleted_at IS NULL`
+	res, err := d.Connection.Exec(query, hash, id)
+	if err != nil {
+		return dbError("ошибка обновления пароля", err)
+	}
+	return checkAffected(res, "пользователь", id)
+}
+
+// RecordLoginAttempt — учитывает попытку входа до проверки пароля: проверка того, открыт ли вход,
+// и увеличение счётчика неудачных попыток идут под блокировкой строки, поэтому параллельные попытки
+// не проходят мимо лимита. Успешный вход затем сбрасывает счётчик (ResetLoginFailures).
+// Счётчик, уже дошедший до блокировки, начинается заново: блокировка снята или истекла.
+// Версия записи не меняется — это состояние входа, а не данные пользователя. Блокировка попадает в журнал
+// только после неверного пароля (RecordLockout): верный пароль сразу сбрасывает счётчик.
+func (d *Database) RecordLoginAttempt(id int64, policy model.LoginPolicy) (model.User, bool, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.User{}, false, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getUserForUpdate(tx, id, false)
+	if err != nil {
+		return model.User{}, false, err
+	}
+	now := time.Now()
+	if before.LockedUntil != nil && now.Before(*before.LockedUntil) {
+		return before, false, nil
+	}
+
+	failures := before.FailedAttempts + 1
+	if policy.MaxAttempts > 0 && before.FailedAttempts >= policy.MaxAttempts {
+		failures = 1
+	}
+	until, _ := policy.LockedUntil(failures, now)
+
+	query := `UPDATE users SET failed_attempts=$1, locked_until=$2 WHERE id=$3`
+	if _, err := tx.Exec(query, failures, until, id); err != nil {
+		return model.User{}, false, dbError("ошибка записи попытки входа", err)
+	}
+
+	after, err := getUserForUpdate(tx, id, false)
+	if err != nil {
+		return model.User{}, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.User{}, false, dbError("ошибка записи попытки входа", err)
+	}
+	return after, true, nil
+}
+
+// RecordLockout — запись в журнал о блокировке входа: before и after — пользователь до и после попытки,
+// которая привела к блокировке
+func (d *Database) RecordLockout(before, after model.User, meta model.AuditMeta) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertAudit(tx, meta, model.AuditLock, model.AuditUser, int64(after.Id), auditChanges(before, after)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return dbError("ошибка записи журнала аудита", err)
+	}
+	return nil
+}
+
+// ResetLoginFailures — сбрасывает счётчик неудачных попыток после успешного входа
+func (d *Database) ResetLoginFailures(id int64) error {
+	query := `UPDATE users SET failed_attempts=0, locked_until=NULL WHERE id=$1 AND deleted_at IS NULL AND failed_attempts > 0`
+	if _, err := d.Connection.Exec(query, id); err != nil {
+		return dbError("ошибка сброса попыток входа", err)
 	}
 	return nil
 }
+
+// UnlockUser — снимает блокировку входа и сбрасывает счётчик неудачных попыток
+func (d *Database) UnlockUser(id int64, meta model.AuditMeta) (model.User, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.User{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getUserForUpdate(tx, id, false)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET failed_attempts=0, locked_until=NULL WHERE id=$1`, id); err != nil {
+		return model.User{}, dbError("ошибка снятия блокировки", err)
+	}
+
+	after, err := getUserForUpdate(tx, id, false)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err := insertAudit(tx, meta, model.AuditUnlock, model.AuditUser, id, auditChanges(before, after)); err != nil {
+		return model.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.User{}, dbError("ошибка снятия блокировки", err)
+	}
+	return after, nil
+}
+
+// HashLegacyPasswords — одноразовая миграция: хеширует все пароли, которые ещё хранятся в открытом виде.
+// Возвращает количество обновлённых пользователей.
+func (d *Database) HashLegacyPasswords() (int, error) {
+	rows, err := d.Connection.Query(`SELECT id, password FROM users`)
+	if err != nil {
+		return 0, dbError("ошибка выполнения запроса", err)
+	}
+
+	legacy := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var stored string
+		if err := rows.Scan(&id, &stored); err != nil {
+			rows.Close()
+			return 0, dbError("ошибка сканирования пользователя", err)
+		}
+		if !password.IsHashed(stored) {
+			legacy[id] = stored
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, dbError("ошибка чтения пользователей", err)
+	}
+
+	updated := 0
+	for id, plain := range legacy {
+		hash, err := password.Hash(plain)
+		if err != nil {
+			return updated, fmt.Errorf("ошибка хеширования пароля: %v", err)
+		}
+		// Условие по старому значению защищает от перезаписи пароля, изменённого параллельно
+		res, err := d.Connection.Exec(`UPDATE users SET password=$1 WHERE id=$2 AND password=$3`, hash, id, plain)
+		if err != nil {
+			return updated, dbError(fmt.Sprintf("ошибка обновления пароля пользователя %d", id), err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			updated++
+		}
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"errors"
+	"go.mod/internal/model"
+	"time"
+)
+
+var (
+	// Запись не найдена (проверяется через errors.Is)
+	ErrNotFound = errors.New("запись не найдена")
+	// Нарушено ограничение уникальности
+	ErrDuplicate = errors.New("запись уже существует")
+	// На запись ссылаются другие записи или запись ссылается на несуществующую
+	ErrReferenced = errors.New("нарушена ссылочная целостность")
+	// Изменение образует цикл в иерархии
+	ErrCycle = errors.New("цикл в иерархии")
+	// Запись изменена после того, как клиент получил её версию
+	ErrVersionMismatch = errors.New("версия записи не совпадает")
+	// Запрос не уложился в отведённое время или не дождался блокировки
+	ErrTimeout = errors.New("превышено время ожидания базы данных")
+	// Нет соединения с базой данных
+	ErrUnavailable = errors.New("база данных недоступна")
+	// Недопустимая смена статуса сотрудника (см. model.StatusTransitions)
+	ErrStatusTransition = errors.New("недопустимая смена статуса")
+)
+
+// Работа с сотрудниками. Удаление мягкое: запись скрывается из выборок и может быть восстановлена
+// до окончательного удаления через Purge. version в Update и Delete — ожидаемая версия записи
+// (ErrVersionMismatch, если она уже изменилась), 0 — без проверки. Смена статуса проверяется
+// по model.StatusTransitions (ErrStatusTransition) и пишется в историю статусов с причиной meta.Reason.
+// scope — область доступа пользователя (model.Scope): сотрудник вне её не найден (ErrNotFound)
+// и не попадает в списки; ограничение входит в условие запроса.
+type EmployeeRepository interface {
+	GetEmployeeByID(id int64, includeDeleted bool, scope model.Scope) (model.Employee, error)
+	ListEmployees(query model.EmployeeQuery) (model.EmployeePage, error)
+	SearchEmployees(query string, limit int, scope model.Scope, fields model.FieldView) ([]model.EmployeeSearchResult, error)
+	CreateEmployee(employee model.Employee, meta model.AuditMeta) (int64, error)
+	UpdateEmployee(id int64, version int, employee model.Employee, scope model.Scope, meta model.AuditMeta) (model.Employee, error)
+	PatchEmployee(id int64, version int, employee model.Employee, fields []string, scope model.Scope, meta model.AuditMeta) (model.Employee, error)
+	DeleteEmployee(id int64, version int, scope model.Scope, meta model.AuditMeta) error
+	RestoreEmployee(id int64, scope model.Scope, meta model.AuditMeta) error
+	PurgeEmployees(before time.Time, meta model.AuditMeta) (int, error)
+	GetStatusHistory(id int64, scope model.Scope) ([]model.StatusChange, error)
+
+	// Кадровая история: изменения должности, отдела и занятости пишутся в неё вместе с изменением сотрудника.
+	// Действия с будущей датой вступают в силу через ApplyJobAssignments.
+	GetEmployeeAsOf(id int64, date model.Date, scope model.Scope) (model.Employee, error)
+	GetJobHistory(id int64, scope model.Scope) ([]model.JobAssignment, error)
+	CreateJobAssignment(assignment model.JobAssignment, today model.Date, meta model.AuditMeta) (model.JobAssignment, error)
+	ApplyJobAssignments(today model.Date, meta model.AuditMeta) (int, error)
+
+	// Подчинённость
+	GetAllEmployees(scope model.Scope) ([]model.Employee, error)
+	GetDirectReports(managerID int64, scope model.Scope) ([]model.Employee, error)
+	GetManagerChain(id int64, scope model.Scope) ([]model.Employee, error)
+	GetSubordinates(id int64, scope model.Scope) ([]model.Employee, error)
+}
+
+// Чтение журнала аудита (записи добавляются вместе с изменениями сотрудников и пользователей)
+type AuditRepository interface {
+	ListAudit(query model.AuditQuery) (model.AuditPage, error)
+}
+
+// Работа с отделами
+type DepartmentRepository interface {
+	GetDepartmentByID(id int64) (model.Department, error)
+	GetDepartmentByName(name string) (model.Department, error)
+	GetAllDepartments() ([]model.Department, error)
+	CreateDepartment(department model.Department) (int64, error)
+	UpdateDepartment(id int64, department model.Department) error
+	DeleteDepartment(id int64) error
+}
+
+// Работа с пользователями. Роли пользователя (model.User.Roles) записываются вместе с ним;
+// неизвестная роль — ErrReferenced.
+type UserRepository interface {
+	GetUserByID(id int64, includeDeleted bool) (model.User, error)
+	GetUserByUsername(username string) (model.User, error)
+	GetAllUsers(includeDeleted bool) ([]model.User, error)
+	CreateUser(user model.User, meta model.AuditMeta) (int64, error)
+	UpdateUser(id int64, version int, user model.User, meta model.AuditMeta) (model.User, error)
+	UpdateUserPassword(id int64, hash string) error
+	// Попытка входа до проверки пароля: если вход открыт, она сразу учитывается как неудачная, и вход закрывается
+	// по policy; counted — false, если вход уже был закрыт (запись не меняется)
+	RecordLoginAttempt(id int64, policy model.LoginPolicy) (user model.User, counted bool, err error)
+	// Записать в журнал блокировку входа после неверного пароля (before и after — до и после попытки)
+	RecordLockout(before, after model.User, meta model.AuditMeta) error
+	// Успешный вход: счётчик неудачных попыток и задержка сбрасываются
+	ResetLoginFailures(id int64) error
+	// Снять блокировку входа (с записью в журнал)
+	UnlockUser(id int64, meta model.AuditMeta) (model.User, error)
+	DeleteUser(id int64, version int, meta model.AuditMeta) error
+	RestoreUser(id int64, meta model.AuditMeta) error
+	PurgeUsers(before time.Time, meta model.AuditMeta) (int, error)
+}
+
+// Роли и разрешения. У роли admin всегда все разрешения (model.Permissions), они не хранятся.
+// Назначенную пользователям роль удалить нельзя (ErrReferenced).
+type RoleRepository interface {
+	GetRoles() ([]model.Role, error)
+	GetRoleByID(id int64) (model.Role, error)
+	CreateRole(role model.Role, meta model.AuditMeta) (int64, error)
+	UpdateRole(id int64, role model.Role, meta model.AuditMeta) (model.Role, error)
+	DeleteRole(id int64, meta model.AuditMeta) error
+	SetUserRoles(userID int64, roles []string, meta model.AuditMeta) (model.User, error)
+	// Разрешения действующего пользователя по всем его ролям
+	GetUserPermissions(username string) ([]string, error)
+}
+
+// Работа с refresh-токенами и списком отозванных токенов
+type TokenRepository interface {
+	CreateRefreshToken(token model.RefreshToken) error
+	UseRefreshToken(tokenHash string) (model.RefreshToken, error)
+	GetTokenFamily(tokenHash string) (string, error)
+	RevokeTokenFamily(familyID string) error
+	RevokeUserTokens(userID int64) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsTokenRevoked(jti string) (bool, error)
+}
+
+// Repository — набор хранилищ, от которых зависят сервисы и обработчики
+type Repository struct {
+	Employees   EmployeeRepository
+	Departments DepartmentRepository
+	Users       UserRepository
+	Roles       RoleRepository
+	Tokens      TokenRepository
+	Audit       AuditRepository
+}
+
+// Хранилища на Postgres
+func NewRepository(db *Database) *Repository {
+	return &Repository{
+		Employees:   db,
+		Departments: db,
+		Users:       db,
+		Roles:       db,
+		Tokens:      db,
+		Audit:       db,
+	}
+}
+
+// Хранилища в памяти (для тестов и локальных демо)
+func NewMemoryRepository(db *MemoryDatabase) *Repository {
+	return &Repository{
+		Employees:   db,
+		Departments: db,
+		Users:       db,
+		Roles:       db,
+		Tokens:      db,
+		Audit:       db,
+	}
+}
+
+// Проверка, что обе реализации удовлетворяют интерфейсам
+var (
+	_ EmployeeRepository   = (*Database)(nil)
+	_ DepartmentRepository = (*Database)(nil)
+	_ UserRepository       = (*Database)(nil)
+	_ RoleRepository       = (*Database)(nil)
+	_ TokenRepository      = (*Database)(nil)
+	_ AuditRepository      = (*Database)(nil)
+
+	_ EmployeeRepository   = (*MemoryDatabase)(nil)
+	_ DepartmentRepository = (*MemoryDatabase)(nil)
+	_ UserRepository       = (*MemoryDatabase)(nil)
+	_ RoleRepository       = (*MemoryDatabase)(nil)
+	_ TokenRepository      = (*MemoryDatabase)(nil)
+	_ AuditRepository      = (*MemoryDatabase)(nil)
+)
@@ -0,0 +1,274 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"go.mod/internal/model"
+	"sort"
+)
+
+// Роли и разрешения
+
+// Колонки роли в порядке полей model.Role; разрешения собираются в массив
+const roleColumns = `r.id, r.name, r.description,
+	ARRAY(SELECT p.permission FROM role_permissions p WHERE p.role_id = r.id ORDER BY p.permission), r.builtin`
+
+func scanRole(row rowScanner) (model.Role, error) {
+	var role model.Role
+	err := row.Scan(&role.Id, &role.Name, &role.Description, pq.Array(&role.Permissions), &role.Builtin)
+	return withAdminPermissions(role), err
+}
+
+// withAdminPermissions — у администратора всегда все разрешения, в том числе появившиеся после создания роли
+func withAdminPermissions(role model.Role) model.Role {
+	if role.Name == model.RoleAdmin {
+		role.Permissions = append([]string(nil), model.Permissions...)
+	}
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	return role
+}
+
+func (d *Database) GetRoles() ([]model.Role, error) {
+	rows, err := d.Connection.Query(`SELECT ` + roleColumns + ` FROM roles r ORDER BY r.id`)
+	if err != nil {
+		return nil, dbError("ошибка получения ролей", err)
+	}
+	defer rows.Close()
+
+	roles := []model.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, dbError("ошибка чтения ролей", err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения ролей", err)
+	}
+	return roles, nil
+}
+
+func (d *Database) GetRoleByID(id int64) (model.Role, error) {
+	return getRole(d.Connection.QueryRow(`SELECT `+roleColumns+` FROM roles r WHERE r.id=$1`, id), id)
+}
+
+func getRole(row rowScanner, id int64) (model.Role, error) {
+	role, err := scanRole(row)
+	if err == sql.ErrNoRows {
+		return role, fmt.Errorf("роль с id %d не найдена: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return role, dbError("ошибка получения роли", err)
+	}
+	return role, nil
+}
+
+// Создать роль с разрешениями, возвращает её ID
+func (d *Database) CreateRole(role model.Role, meta model.AuditMeta) (int64, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRow(`INSERT INTO roles (name, description) VALUES ($1, $2) RETURNING id`, role.Name, role.Description).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("роль %s уже существует: %w", role.Name, ErrDuplicate)
+		}
+		return 0, dbError("ошибка добавления роли", err)
+	}
+	if err := setRolePermissions(tx, id, role.Permissions); err != nil {
+		return 0, err
+	}
+
+	role.Builtin = false
+	sort.Strings(role.Permissions)
+	if err := insertAudit(tx, meta, model.AuditCreate, model.AuditRole, id, auditChanges(nil, role)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError("ошибка добавления роли", err)
+	}
+	return id, nil
+}
+
+// Изменить описание и разрешения роли (имя роли не меняется: по нему роль назначена пользователям)
+func (d *Database) UpdateRole(id int64, role model.Role, meta model.AuditMeta) (model.Role, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.Role{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getRole(tx.QueryRow(`SELECT `+roleColumns+` FROM roles r WHERE r.id=$1 FOR UPDATE`, id), id)
+	if err != nil {
+		return model.Role{}, err
+	}
+
+	if _, err := tx.Exec(`UPDATE roles SET description=$1 WHERE id=$2`, role.Description, id); err != nil {
+		return model.Role{}, dbError("ошибка обновления роли", err)
+	}
+	if err := setRolePermissions(tx, id, role.Permissions); err != nil {
+		return model.Role{}, err
+	}
+
+	after, err := getRole(tx.QueryRow(`SELECT `+roleColumns+` FROM roles r WHERE r.id=$1`, id), id)
+	if err != nil {
+		return model.Role{}, err
+	}
+	if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditRole, id, auditChanges(before, after)); err != nil {
+		return model.Role{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Role{}, dbError("ошибка обновления роли", err)
+	}
+	return after, nil
+}
+
+// setRolePermissions — заменяет разрешения роли
+func setRolePermissions(tx *sql.Tx, id int64, permissions []string) error {
+	if _, err := tx.Exec(`DELETE FROM role_permissions WHERE role_id=$1`, id); err != nil {
+		return dbError("ошибка обновления разрешений роли", err)
+	}
+	query := `INSERT INTO role_permissions (role_id, permission) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING`
+	if _, err := tx.Exec(query, id, pq.Array(permissions)); err != nil {
+		return dbError("ошибка обновления разрешений роли", err)
+	}
+	return nil
+}
+
+// Удалить роль; встроенную или назначенную пользователям — нельзя (ErrReferenced)
+func (d *Database) DeleteRole(id int64, meta model.AuditMeta) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	role, err := getRole(tx.QueryRow(`SELECT `+roleColumns+` FROM roles r WHERE r.id=$1 FOR UPDATE`, id), id)
+	if err != nil {
+		return err
+	}
+	if role.Builtin {
+		return fmt.Errorf("встроенную роль %s нельзя удалить: %w", role.Name, ErrReferenced)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM roles WHERE id=$1`, id); err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("роль %s назначена пользователям: %w", role.Name, ErrReferenced)
+		}
+		return dbError("ошибка удаления роли", err)
+	}
+	if err := insertAudit(tx, meta, model.AuditDelete, model.AuditRole, id, auditChanges(role, nil)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError("ошибка удаления роли", err)
+	}
+	return nil
+}
+
+// Назначить пользователю роли (заменяет прежние) и вернуть его запись
+func (d *Database) SetUserRoles(userID int64, roles []string, meta model.AuditMeta) (model.User, error) {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return model.User{}, dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	before, err := getUserForUpdate(tx, userID, false)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err := setUserRoles(tx, userID, roles); err != nil {
+		return model.User{}, err
+	}
+	if _, err := tx.Exec(`UPDATE users SET version=version+1 WHERE id=$1`, userID); err != nil {
+		return model.User{}, dbError("ошибка назначения ролей", err)
+	}
+
+	after, err := getUserForUpdate(tx, userID, false)
+	if err != nil {
+		return model.User{}, err
+	}
+	if err := insertAudit(tx, meta, model.AuditUpdate, model.AuditUser, userID, auditChanges(before, after)); err != nil {
+		return model.User{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.User{}, dbError("ошибка назначения ролей", err)
+	}
+	return after, nil
+}
+
+// setUserRoles — заменяет роли пользователя; ErrReferenced, если какой-то роли нет
+func setUserRoles(tx *sql.Tx, userID int64, roles []string) error {
+	if _, err := tx.Exec(`DELETE FROM user_roles WHERE user_id=$1`, userID); err != nil {
+		return dbError("ошибка назначения ролей", err)
+	}
+	res, err := tx.Exec(`INSERT INTO user_roles (user_id, role_id) SELECT $1, id FROM roles WHERE name = ANY($2)`, userID, pq.Array(roles))
+	if err != nil {
+		return dbError("ошибка назначения ролей", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return dbError("ошибка назначения ролей", err)
+	} else if int(n) != len(uniqueStrings(roles)) {
+		return fmt.Errorf("роль не найдена: %w", ErrReferenced)
+	}
+	return nil
+}
+
+// uniqueStrings — строки без повторов в порядке первого появления
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+func (d *Database) GetUserPermissions(username string) ([]string, error) {
+	query := `SELECT r.name, p.permission
+			  FROM users u
+			  JOIN user_roles ur ON ur.user_id = u.id
+			  JOIN roles r ON r.id = ur.role_id
+			  LEFT JOIN role_permissions p ON p.role_id = r.id
+			  WHERE u.username = $1 AND u.deleted_at IS NULL`
+	rows, err := d.Connection.Query(query, username)
+	if err != nil {
+		return nil, dbError("ошибка получения разрешений", err)
+	}
+	defer rows.Close()
+
+	var permissions []string
+	for rows.Next() {
+		var role string
+		var permission sql.NullString
+		if err := rows.Scan(&role, &permission); err != nil {
+			return nil, dbError("ошибка чтения разрешений", err)
+		}
+		if role == model.RoleAdmin {
+			return append([]string(nil), model.Permissions...), nil
+		}
+		if permission.Valid {
+			permissions = append(permissions, permission.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения разрешений", err)
+	}
+	return uniqueStrings(permissions), nil
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"go.mod/internal/model"
+	"go.mod/pkg/translit"
+	"strings"
+	"unicode"
+)
+
+// Поиск сотрудников
+
+// searchTerms — разобранная строка поиска
+type searchTerms struct {
+	words  []string // Слова запроса в нижнем регистре (только буквы и цифры)
+	latin  string   // Запрос латиницей для нечёткого сравнения
+	digits string   // Цифры запроса для поиска по фрагменту телефона
+	raw    string
+}
+
+// Фрагмент телефона короче этого не ищется, иначе совпадает почти всё
+const minPhoneDigits = 3
+
+func parseSearch(query string) searchTerms {
+	terms := searchTerms{raw: strings.TrimSpace(query)}
+	terms.words = tokenize(terms.raw)
+	terms.latin = translit.ToLatin(strings.Join(terms.words, " "))
+	if digits := onlyDigits(terms.raw); len(digits) >= minPhoneDigits {
+		terms.digits = digits
+	}
+	return terms
+}
+
+// tokenize — слова строки в нижнем регистре (только буквы и цифры)
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// tsQuery — все слова запроса как префиксы: "хол дос" -> "хол:* & дос:*"
+func (t searchTerms) tsQuery() string {
+	parts := make([]string, len(t.words))
+	for i, w := range t.words {
+		parts[i] = w + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// escapeLike — экранирование спецсимволов шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// SearchEmployees — полнотекстовый поиск по ФИО, должности, отделу и заметкам,
+// нечёткий поиск по ФИО с учётом транслитерации, поиск по фрагменту телефона и почты.
+// Результаты отсортированы по релевантности и ограничены областью доступа scope. Заметки, телефон и почта
+// участвуют в поиске и фрагменте, только если пользователь видит их полностью (fields).
+func (d *Database) SearchEmployees(query string, limit int, scope model.Scope, fields model.FieldView) ([]model.EmployeeSearchResult, error) {
+	terms := parseSearch(query)
+	filter, args := scopeFilter(scope, []interface{}{
+		terms.tsQuery(),
+		terms.latin,
+		terms.digits,
+		"%" + escapeLike(terms.raw) + "%",
+		limit,
+		fields.Visible("notes"),
+		fields.Visible("phonenumber"),
+		fields.Visible("email"),
+	})
+
+	// $1 — tsquery, $2 — запрос латиницей, $3 — цифры телефона, $4 — шаблон почты, $5 — лимит,
+	// $6, $7, $8 — искать ли по заметкам, телефону и почте, $9 — отдел области доступа
+	sqlQuery := `SELECT ` + employeeColumns + `,
+			greatest(
+				ts_rank(v.document, to_tsquery('simple', $1)),
+				word_similarity($2, e.search_latin),
+				CASE WHEN $7 AND $3 <> '' AND regexp_replace(e.phonenumber, '\D', '', 'g') LIKE '%' || $3 || '%' THEN 0.5::real ELSE 0 END,
+				CASE WHEN $8 AND e.email ILIKE $4 THEN 0.5::real ELSE 0 END
+			) AS rank,
+			ts_headline('simple',
+				replace(replace(replace(concat_ws(' ', e.lastname, e.firstname, e.middlename, e.position, d.name, CASE WHEN $6 THEN e.notes END),
+					'&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+				to_tsquery('simple', $1),
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=3, MaxWords=15'
+			) AS snippet` + employeeTables + `
+		-- Название отдела хранится в departments, поэтому добавляется к вектору здесь;
+		-- заметки (вес C) убираются из вектора, если пользователь их не видит
+		CROSS JOIN LATERAL (
+			SELECT CASE WHEN $6 THEN e.search_vector ELSE ts_filter(e.search_vector, '{a,b}') END
+				|| setweight(to_tsvector('simple', coalesce(d.name, '')), 'B') AS document
+		) v
+		WHERE e.deleted_at IS NULL AND (
+			v.document @@ to_tsquery('simple', $1)
+			OR $2 <% e.search_latin
+			OR ($7 AND $3 <> '' AND regexp_replace(e.phonenumber, '\D', '', 'g') LIKE '%' || $3 || '%')
+			OR ($8 AND e.email ILIKE $4)
+		)` + filter + `
+		ORDER BY rank DESC, e.id
+		LIMIT $5`
+
+	rows, err := d.Connection.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, dbError("ошибка поиска сотрудников", err)
+	}
+	defer rows.Close()
+
+	results := []model.EmployeeSearchResult{}
+	for rows.Next() {
+		var r model.EmployeeSearchResult
+		e := &r.Employee
+		if err := rows.Scan(
+			&e.Id, &e.LastName, &e.FirstName, &e.MiddleName, &e.Position, &e.Department, &e.DepartmentId, &e.ManagerId, &e.Email,
+			&e.PhoneNumber, &e.HireDate, &e.ProbationEndDate, &e.TerminationDate, &e.Status, &e.PhotoUrl, &e.Notes, &e.Version, &e.DeletedAt, &e.DeletedBy,
+			&r.Rank, &r.Snippet,
+		); err != nil {
+			return nil, dbError("ошибка чтения результатов поиска", err)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения результатов поиска", err)
+	}
+	return results, nil
+}
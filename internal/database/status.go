@@ -0,0 +1,65 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"go.mod/internal/model"
+)
+
+// История статусов сотрудника
+
+// checkStatusTransition — смена статуса from на to разрешена model.StatusTransitions
+func checkStatusTransition(id int64, from, to string) error {
+	if !model.CanTransition(from, to) {
+		return fmt.Errorf("сотрудник %d: статус %q нельзя сменить на %q: %w", id, from, to, ErrStatusTransition)
+	}
+	return nil
+}
+
+// insertStatusChange — запись в историю статусов в той же транзакции, что и само изменение (если статус изменился)
+func insertStatusChange(tx *sql.Tx, meta model.AuditMeta, id int64, from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	query := `INSERT INTO employee_status_history (employee_id, from_status, to_status, reason, changed_by, request_id)
+			  VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.Exec(query, id, from, to, meta.Reason, meta.Actor, meta.RequestID); err != nil {
+		return dbError("ошибка записи истории статусов", err)
+	}
+	return nil
+}
+
+// История статусов сотрудника (в порядке смены); доступна и для удалённого сотрудника.
+// ErrNotFound, если сотрудник не входит в область доступа scope.
+func (d *Database) GetStatusHistory(id int64, scope model.Scope) ([]model.StatusChange, error) {
+	var exists bool
+	filter, args := scopeFilter(scope, []interface{}{id})
+	if err := d.Connection.QueryRow(`SELECT EXISTS (SELECT 1 FROM employees e WHERE e.id=$1`+filter+`)`, args...).Scan(&exists); err != nil {
+		return nil, dbError("ошибка получения сотрудника", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("сотрудник с id %d не найден: %w", id, ErrNotFound)
+	}
+
+	query := `SELECT id, employee_id, from_status, to_status, reason, changed_at, changed_by, request_id
+			  FROM employee_status_history WHERE employee_id=$1 ORDER BY id`
+	rows, err := d.Connection.Query(query, id)
+	if err != nil {
+		return nil, dbError("ошибка получения истории статусов", err)
+	}
+	defer rows.Close()
+
+	history := []model.StatusChange{}
+	for rows.Next() {
+		var c model.StatusChange
+		if err := rows.Scan(&c.Id, &c.EmployeeId, &c.From, &c.To, &c.Reason, &c.ChangedAt, &c.ChangedBy, &c.RequestId); err != nil {
+			return nil, dbError("ошибка чтения истории статусов", err)
+		}
+		history = append(history, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("ошибка чтения истории статусов", err)
+	}
+	return history, nil
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"go.mod/internal/model"
+	"time"
+)
+
+var (
+	// Refresh-токен не найден или истёк
+	ErrTokenInvalid = errors.New("недействительный refresh-токен")
+	// Refresh-токен уже был использован или отозван — признак кражи, семейство отозвано
+	ErrTokenReused = errors.New("повторное использование refresh-токена")
+)
+
+// Токены
+
+// Сохранить новый refresh-токен
+func (d *Database) CreateRefreshToken(token model.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (user_id, family_id, token_hash, access_jti, access_expires_at, expires_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := d.Connection.Exec(query,
+		token.UserId,
+		token.FamilyId,
+		token.TokenHash,
+		token.AccessJti,
+		token.AccessExpiresAt,
+		token.ExpiresAt,
+	)
+	if err != nil {
+		return dbError("ошибка сохранения refresh-токена", err)
+	}
+	return nil
+}
+
+// UseRefreshToken — атомарно помечает refresh-токен использованным и возвращает его.
+// Если токен уже использован или отозван, всё семейство отзывается и возвращается ErrTokenReused.
+func (d *Database) UseRefreshToken(tokenHash string) (model.RefreshToken, error) {
+	query := `UPDATE refresh_tokens SET used_at=now()
+			  WHERE token_hash=$1 AND used_at IS NULL AND revoked_at IS NULL AND expires_at > now()
+			  RETURNING id, user_id, family_id, expires_at`
+
+	var token model.RefreshToken
+	err := d.Connection.QueryRow(query, tokenHash).Scan(
+		&token.Id,
+		&token.UserId,
+		&token.FamilyId,
+		&token.ExpiresAt,
+	)
+	if err == nil {
+		token.TokenHash = tokenHash
+		return token, nil
+	}
+	if err != sql.ErrNoRows {
+		return token, dbError("ошибка обновления refresh-токена", err)
+	}
+
+	// Токен не подошёл — выясняем, существует ли он вообще
+	var usedAt, revokedAt sql.NullTime
+	err = d.Connection.QueryRow(
+		`SELECT family_id, used_at, revoked_at FROM refresh_tokens WHERE token_hash=$1`,
+		tokenHash,
+	).Scan(&token.FamilyId, &usedAt, &revokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return token, ErrTokenInvalid
+		}
+		return token, dbError("ошибка получения refresh-токена", err)
+	}
+
+	if usedAt.Valid || revokedAt.Valid {
+		if err := d.RevokeTokenFamily(token.FamilyId); err != nil {
+			return token, err
+		}
+		return token, ErrTokenReused
+	}
+
+	// Токен просто истёк
+	return token, ErrTokenInvalid
+}
+
+// Получить семейство refresh-токена (для выхода)
+func (d *Database) GetTokenFamily(tokenHash string) (string, error) {
+	var familyID string
+	err := d.Connection.QueryRow(`SELECT family_id FROM refresh_tokens WHERE token_hash=$1`, tokenHash).Scan(&familyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrTokenInvalid
+		}
+		return "", dbError("ошибка получения refresh-токена", err)
+	}
+	return familyID, nil
+}
+
+// RevokeTokenFamily — отзывает все refresh-токены семейства и выданные вместе с ними access-токены
+func (d *Database) RevokeTokenFamily(familyID string) error {
+	return d.revokeTokens(`family_id=$1`, familyID)
+}
+
+// RevokeUserTokens — отзывает все токены пользователя (удаление, смена роли или пароля)
+func (d *Database) RevokeUserTokens(userID int64) error {
+	return d.revokeTokens(`user_id=$1`, userID)
+}
+
+func (d *Database) revokeTokens(condition string, arg interface{}) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return dbError("ошибка начала транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// Ещё не истёкшие access-токены семейства попадают в список отозванных
+	_, err = tx.Exec(`INSERT INTO revoked_tokens (jti, expires_at)
+					  SELECT access_jti, access_expires_at FROM refresh_tokens
+					  WHERE `+condition+` AND access_expires_at > now()
+					  ON CONFLICT (jti) DO NOTHING`, arg)
+	if err != nil {
+		return dbError("ошибка отзыва access-токенов", err)
+	}
+
+	_, err = tx.Exec(`UPDATE refresh_tokens SET revoked_at=now() WHERE `+condition+` AND revoked_at IS NULL`, arg)
+	if err != nil {
+		return dbError("ошибка отзыва refresh-токенов", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError("ошибка сохранения транзакции", err)
+	}
+	return nil
+}
+
+// Отозвать один access-токен по jti
+func (d *Database) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	_, err := d.Connection.Exec(
+		`INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING`,
+		jti, expiresAt,
+	)
+	if err != nil {
+		return dbError("ошибка отзыва токена", err)
+	}
+
+	// Заодно удаляем записи, срок действия которых уже закончился
+	if _, err := d.Connection.Exec(`DELETE FROM revoked_tokens WHERE expires_at < now()`); err != nil {
+		return dbError("ошибка очистки отозванных токенов", err)
+	}
+	return nil
+}
+
+// Проверить, отозван ли access-токен
+func (d *Database) IsTokenRevoked(jti string) (bool, error) {
+	var exists bool
+	err := d.Connection.QueryRow(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti=$1)`, jti).Scan(&exists)
+	if err != nil {
+		return false, dbError("ошибка проверки токена", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"encoding/json"
+	"go.mod/internal/model"
+	"net/http"
+	"strconv"
+)
+
+// Обработчики /api/v1, у которых ответ отличается от старых маршрутов: создание возвращает
+// 201 с Location и созданной записью, замена — обновлённую запись, удаление и восстановление — 204.
+// Чтение и остальные операции используют те же обработчики, что и старые маршруты.
+
+// Префикс версии API
+const apiV1 = "/api/v1"
+
+// writeJSON — ответ с JSON-телом и заданным статусом
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		return
+	}
+}
+
+// location — адрес ресурса в /api/v1
+func location(collection string, id int64) string {
+	return apiV1 + "/" + collection + "/" + strconv.FormatInt(id, 10)
+}
+
+// Создать сотрудника
+//
+//	POST /api/v1/employees -> 201, Location: /api/v1/employees/5
+func (h *Handlers) CreateEmployeeV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req employeeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	actor := actorFromRequest(r)
+	id, err := h.service.Employees.Create(actor, req.toModel())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	created, err := h.service.Employees.Get(actor, id, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", location("employees", id))
+	resp := newEmployeeResponse(created, h.fieldView(r))
+	w.Header().Set("ETag", employeeETag(resp))
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// Заменить данные сотрудника целиком; версия записи обязательна в If-Match
+//
+//	PUT /api/v1/employees/5
+func (h *Handlers) ReplaceEmployeeV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req employeeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.Employees.Update(actorFromRequest(r), id, version, req.toModel())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := newEmployeeResponse(updated, h.fieldView(r))
+	w.Header().Set("ETag", employeeETag(resp))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Удалить сотрудника; версия записи обязательна в If-Match
+//
+//	DELETE /api/v1/employees/5 -> 204
+func (h *Handlers) DeleteEmployeeV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Employees.Delete(actorFromRequest(r), id, version); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Восстановить удалённого сотрудника
+//
+//	POST /api/v1/employees/5/restore -> 204
+func (h *Handlers) RestoreEmployeeV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Employees.Restore(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Создать отдел
+//
+//	POST /api/v1/departments -> 201, Location: /api/v1/departments/3
+func (h *Handlers) CreateDepartmentV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var department model.Department
+	if !decodeJSON(w, r, &department) {
+		return
+	}
+
+	actor := actorFromRequest(r)
+	id, err := h.service.Departments.Create(actor, department)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	created, err := h.service.Departments.Get(actor, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", location("departments", id))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// Заменить данные отдела
+//
+//	PUT /api/v1/departments/3
+func (h *Handlers) ReplaceDepartmentV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var department model.Department
+	if !decodeJSON(w, r, &department) {
+		return
+	}
+
+	actor := actorFromRequest(r)
+	if err := h.service.Departments.Update(actor, id, department); err != nil {
+		writeError(w, err)
+		return
+	}
+	updated, err := h.service.Departments.Get(actor, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// Удалить отдел
+//
+//	DELETE /api/v1/departments/3 -> 204
+func (h *Handlers) DeleteDepartmentV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Departments.Delete(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Создать пользователя
+//
+//	POST /api/v1/users -> 201, Location: /api/v1/users/7
+func (h *Handlers) CreateUserV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req userRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	actor := actorFromRequest(r)
+	id, err := h.service.Users.Create(actor, req.toModel())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	created, err := h.service.Users.Get(actor, id, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", location("users", id))
+	resp := newUserResponse(created)
+	w.Header().Set("ETag", userETag(resp))
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// Заменить данные пользователя; версия записи обязательна в If-Match
+//
+//	PUT /api/v1/users/7
+func (h *Handlers) ReplaceUserV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req userRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.Users.Update(actorFromRequest(r), id, version, req.toModel())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := newUserResponse(updated)
+	w.Header().Set("ETag", userETag(resp))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Удалить пользователя; версия записи обязательна в If-Match
+//
+//	DELETE /api/v1/users/7 -> 204
+func (h *Handlers) DeleteUserV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Users.Delete(actorFromRequest(r), id, version); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Восстановить удалённого пользователя
+//
+//	POST /api/v1/users/7/restore -> 204
+func (h *Handlers) RestoreUserV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Users.Restore(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Снять блокировку входа после неудачных попыток
+//
+//	POST /api/v1/users/7/unlock -> 200 с пользователем
+func (h *Handlers) UnlockUserV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	unlocked, err := h.service.Users.Unlock(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := newUserResponse(unlocked)
+	w.Header().Set("ETag", userETag(resp))
+	writeJSON(w, http.StatusOK, resp)
+}
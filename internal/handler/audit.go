@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"go.mod/internal/model"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// История изменений сотрудника (новые записи первыми)
+//
+//	GET /employees/5/history?limit=50&offset=0
+func (h *Handlers) GetEmployeeHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		invalidParam(w, "id")
+		return
+	}
+
+	var q model.AuditQuery
+	if err := parsePage(r.URL.Query(), &q); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := h.service.Employees.History(actorFromRequest(r), id, q.Limit, q.Offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		return
+	}
+}
+
+// Журнал аудита с фильтрами; from и to — RFC 3339 или ГГГГ-ММ-ДД (UTC)
+//
+//	GET /audit?entity=user&entity_id=3&actor=admin&action=update&request_id=...&from=2025-01-01&to=2025-02-01
+func (h *Handlers) GetAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := r.URL.Query()
+	q := model.AuditQuery{
+		Entity:    values.Get("entity"),
+		Actor:     values.Get("actor"),
+		Action:    values.Get("action"),
+		RequestID: values.Get("request_id"),
+	}
+
+	var err error
+	if v := values.Get("entity_id"); v != "" {
+		if q.EntityID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			invalidParam(w, "entity_id")
+			return
+		}
+	}
+	if q.From, err = parseTime(values.Get("from")); err != nil {
+		invalidParam(w, "from")
+		return
+	}
+	if q.To, err = parseTime(values.Get("to")); err != nil {
+		invalidParam(w, "to")
+		return
+	}
+	if err := parsePage(values, &q); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := h.service.Audit.List(actorFromRequest(r), q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		return
+	}
+}
+
+// parsePage — limit и offset журнала аудита
+func parsePage(values url.Values, q *model.AuditQuery) error {
+	var err error
+	if v := values.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil {
+			return &paramError{name: "limit"}
+		}
+	}
+	if v := values.Get("offset"); v != "" {
+		if q.Offset, err = strconv.Atoi(v); err != nil {
+			return &paramError{name: "offset"}
+		}
+	}
+	return nil
+}
+
+// parseTime — момент времени в RFC 3339 или дата ГГГГ-ММ-ДД (полночь UTC); пустая строка — нулевое время
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go.mod/internal/model"
+	"go.mod/internal/service"
+	"go.mod/pkg/i18n"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Максимальный размер тела запроса на создание и изменение записи
+const maxBodySize = 1 << 20
+
+// decodeJSON — разбирает тело запроса (JSON-объект) в структуру dst, на которую указывает указатель.
+// Неизвестные поля, поля только для чтения (см. readOnlyFields) и значения неверного типа возвращаются
+// одним ответом 400 со списком всех полей; при ошибке ответ уже отправлен.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		httpError(w, "error.invalid_body", http.StatusBadRequest)
+		return false
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil || raw == nil {
+		httpError(w, "error.invalid_body", http.StatusBadRequest)
+		return false
+	}
+
+	rv := reflect.ValueOf(dst).Elem()
+	fields := jsonFields(rv.Type())
+	readOnly := make(map[string]bool)
+	if ro, ok := dst.(readOnlyFields); ok {
+		for _, name := range ro.readOnly() {
+			readOnly[name] = true
+		}
+	}
+	errs := make(map[string]i18n.Text)
+	for name, value := range raw {
+		i, ok := fields[name]
+		if !ok {
+			errs[name] = i18n.T("validation.unknown_field")
+			if readOnly[name] {
+				errs[name] = i18n.T(readOnlyMessage(name))
+			}
+			continue
+		}
+		// Каждое поле разбирается отдельно, чтобы собрать ошибки по всем полям
+		field := rv.Field(i)
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		if err := decoder.Decode(field.Addr().Interface()); errors.Is(err, model.ErrInvalidDate) {
+			errs[name] = i18n.T("validation.date")
+		} else if err != nil {
+			errs[name] = i18n.T("validation.type", i18n.Args{"type": jsonType(field.Type())})
+		}
+	}
+	if len(errs) > 0 {
+		writeError(w, &service.ValidationError{Fields: errs})
+		return false
+	}
+	return true
+}
+
+// readOnlyMessage — сообщение о поле только для чтения, то же, что при PATCH
+func readOnlyMessage(name string) string {
+	switch name {
+	case "version":
+		return "validation.version_read_only"
+	case "deleted_at", "deleted_by":
+		return "validation.deleted_read_only"
+	default:
+		return "validation.read_only"
+	}
+}
+
+// jsonFields — индексы полей структуры по json-именам
+func jsonFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" && t.Field(i).IsExported() {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// jsonType — название типа JSON для сообщения о неверном значении
+func jsonType(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"go.mod/internal/model"
+	"net/http"
+	"strconv"
+)
+
+// Получить отдел по ID
+func (h *Handlers) GetDepartment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	department, err := h.service.Departments.Get(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(department)
+	if err != nil {
+		return
+	}
+}
+
+// Получить все отделы; с параметром tree=true — деревом с вложенными подотделами
+func (h *Handlers) GetAllDepartments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tree := false
+	if value := r.URL.Query().Get("tree"); value != "" {
+		var err error
+		if tree, err = strconv.ParseBool(value); err != nil {
+			invalidParam(w, "tree")
+			return
+		}
+	}
+
+	var result interface{}
+	var err error
+	if tree {
+		result, err = h.service.Departments.Tree(actorFromRequest(r))
+	} else {
+		result, err = h.service.Departments.List(actorFromRequest(r))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		return
+	}
+}
+
+// Создать отдел
+func (h *Handlers) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var department model.Department
+	if !decodeJSON(w, r, &department) {
+		return
+	}
+
+	id, err := h.service.Departments.Create(actorFromRequest(r), department)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "message": tr(w, "department.created")})
+	if err != nil {
+		return
+	}
+}
+
+// Обновить отдел
+func (h *Handlers) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var department model.Department
+	if !decodeJSON(w, r, &department) {
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Departments.Update(actorFromRequest(r), id, department); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "department.updated")})
+	if err != nil {
+		return
+	}
+}
+
+// Удалить отдел
+func (h *Handlers) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Departments.Delete(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "department.deleted")})
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"go.mod/internal/model"
+	"time"
+)
+
+// Представления пользователей и сотрудников в API. Запросы и ответы отделены от моделей хранилища:
+// колонки таблиц можно менять, не ломая клиентов, а служебные поля (пароль) не попадают в ответы.
+// Имена полей совпадают с прежними, чтобы существующие клиенты продолжали работать.
+
+// readOnlyFields — поля ответа, которые клиент не может задать; в теле запроса они отклоняются
+// как поля только для чтения (а не неизвестные), чтобы было понятно, что поле существует.
+type readOnlyFields interface {
+	readOnly() []string
+}
+
+// Тело запроса на создание и изменение пользователя. Пароль только записывается: в ответах его нет.
+// При изменении пустой пароль оставляет прежний. Прежнее поле role (одна роль) принимается,
+// если roles не указано.
+type userRequest struct {
+	Username     string   `json:"username"`
+	Password     string   `json:"password"`
+	Roles        []string `json:"roles"`
+	Role         string   `json:"role"`
+	DepartmentId *int     `json:"department_id"`
+	Locale       string   `json:"locale"`
+}
+
+func (userRequest) readOnly() []string {
+	return []string{"id", "version", "deleted_at", "deleted_by"}
+}
+
+func (u userRequest) toModel() model.User {
+	roles := u.Roles
+	if len(roles) == 0 && u.Role != "" {
+		roles = []string{u.Role}
+	}
+	return model.User{Username: u.Username, Password: u.Password, Roles: roles, DepartmentId: u.DepartmentId, Locale: u.Locale}
+}
+
+// Пользователь в ответе
+type userResponse struct {
+	Id           int      `json:"id"`
+	Username     string   `json:"username"`
+	Roles        []string `json:"roles"`
+	DepartmentId *int     `json:"department_id"`
+	Locale       string   `json:"locale"`
+	Version      int      `json:"version"`
+
+	FailedAttempts int        `json:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until"`
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
+}
+
+func newUserResponse(u model.User) userResponse {
+	roles := u.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return userResponse{
+		Id:           u.Id,
+		Username:     u.Username,
+		Roles:        roles,
+		DepartmentId: u.DepartmentId,
+		Locale:       u.Locale,
+		Version:      u.Version,
+
+		FailedAttempts: u.FailedAttempts,
+		LockedUntil:    u.LockedUntil,
+
+		DeletedAt: u.DeletedAt,
+		DeletedBy: u.DeletedBy,
+	}
+}
+
+func newUserResponses(users []model.User) []userResponse {
+	items := make([]userResponse, 0, len(users))
+	for _, u := range users {
+		items = append(items, newUserResponse(u))
+	}
+	return items
+}
+
+// Роли пользователя: тело запроса на их назначение (заменяет прежние) и ответ со списком
+type userRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// Тело запроса на создание и изменение роли. Имя задаётся только при создании.
+type roleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (roleRequest) readOnly() []string {
+	return []string{"id", "builtin"}
+}
+
+func (r roleRequest) toModel() model.Role {
+	return model.Role{Name: r.Name, Description: r.Description, Permissions: r.Permissions}
+}
+
+// Тело запроса на создание и замену сотрудника. Отдел задаётся department_id
+// или, если он не указан, названием department.
+type employeeRequest struct {
+	LastName         string     `json:"lastname"`
+	FirstName        string     `json:"firstname"`
+	MiddleName       string     `json:"middlename"`
+	Position         string     `json:"position"`
+	Department       string     `json:"department"`
+	DepartmentId     *int       `json:"department_id"`
+	ManagerId        *int       `json:"manager_id"`
+	Email            string     `json:"email"`
+	PhoneNumber      string     `json:"phonenumber"`
+	HireDate         model.Date `json:"hiredate"`
+	ProbationEndDate model.Date `json:"probation_end_date"`
+	TerminationDate  model.Date `json:"termination_date"`
+	Status           string     `json:"status"`
+	PhotoUrl         string     `json:"photourl"`
+	Notes            string     `json:"notes"`
+}
+
+func (employeeRequest) readOnly() []string {
+	return []string{"id", "full_name", "version", "deleted_at", "deleted_by"}
+}
+
+func (e employeeRequest) toModel() model.Employee {
+	return model.Employee{
+		LastName:         e.LastName,
+		FirstName:        e.FirstName,
+		MiddleName:       e.MiddleName,
+		Position:         e.Position,
+		Department:       e.Department,
+		DepartmentId:     e.DepartmentId,
+		ManagerId:        e.ManagerId,
+		Email:            e.Email,
+		PhoneNumber:      e.PhoneNumber,
+		HireDate:         e.HireDate,
+		ProbationEndDate: e.ProbationEndDate,
+		TerminationDate:  e.TerminationDate,
+		Status:           e.Status,
+		PhotoUrl:         e.PhotoUrl,
+		Notes:            e.Notes,
+	}
+}
+
+// Сотрудник в ответе; full_name вычисляется из фамилии, имени и отчества
+// Поля, скрытые от пользователя (model.FieldView), в ответе отсутствуют
+type employeeResponse struct {
+	Id               int         `json:"id"`
+	FullName         string      `json:"full_name"`
+	LastName         string      `json:"lastname"`
+	FirstName        string      `json:"firstname"`
+	MiddleName       string      `json:"middlename"`
+	Position         string      `json:"position"`
+	Department       string      `json:"department"`
+	DepartmentId     *int        `json:"department_id"`
+	ManagerId        *int        `json:"manager_id"`
+	Email            *string     `json:"email,omitempty"`
+	PhoneNumber      *string     `json:"phonenumber,omitempty"`
+	HireDate         *model.Date `json:"hiredate,omitempty"`
+	ProbationEndDate *model.Date `json:"probation_end_date,omitempty"`
+	TerminationDate  *model.Date `json:"termination_date,omitempty"`
+	Status           *string     `json:"status,omitempty"`
+	PhotoUrl         *string     `json:"photourl,omitempty"`
+	Notes            *string     `json:"notes,omitempty"`
+	Version          int         `json:"version"`
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
+}
+
+func newEmployeeResponse(e model.Employee, view model.FieldView) employeeResponse {
+	return employeeResponse{
+		Id:               e.Id,
+		FullName:         e.FullName(),
+		LastName:         e.LastName,
+		FirstName:        e.FirstName,
+		MiddleName:       e.MiddleName,
+		Position:         e.Position,
+		Department:       e.Department,
+		DepartmentId:     e.DepartmentId,
+		ManagerId:        e.ManagerId,
+		Email:            shown(view, "email", e.Email),
+		PhoneNumber:      shown(view, "phonenumber", e.PhoneNumber),
+		HireDate:         shown(view, "hiredate", e.HireDate),
+		ProbationEndDate: shown(view, "probation_end_date", e.ProbationEndDate),
+		TerminationDate:  shown(view, "termination_date", e.TerminationDate),
+		Status:           shown(view, "status", e.Status),
+		PhotoUrl:         shown(view, "photourl", e.PhotoUrl),
+		Notes:            shown(view, "notes", e.Notes),
+		Version:          e.Version,
+		DeletedAt:        e.DeletedAt,
+		DeletedBy:        e.DeletedBy,
+	}
+}
+
+// shown — значение поля field для ответа; nil, если поле скрыто от пользователя
+func shown[T any](view model.FieldView, field string, value T) *T {
+	if view.Hidden(field) {
+		return nil
+	}
+	return &value
+}
+
+func newEmployeeResponses(employees []model.Employee, view model.FieldView) []employeeResponse {
+	items := make([]employeeResponse, 0, len(employees))
+	for _, e := range employees {
+		items = append(items, newEmployeeResponse(e, view))
+	}
+	return items
+}
+
+// Страница списка сотрудников в ответе
+type employeePageResponse struct {
+	Items      []employeeResponse `json:"items"`
+	Total      int                `json:"total"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+func newEmployeePageResponse(page model.EmployeePage, view model.FieldView) employeePageResponse {
+	return employeePageResponse{
+		Items:      newEmployeeResponses(page.Items, view),
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// Результат поиска сотрудника в ответе
+type employeeSearchResponse struct {
+	employeeResponse
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+func newEmployeeSearchResponses(results []model.EmployeeSearchResult, view model.FieldView) []employeeSearchResponse {
+	items := make([]employeeSearchResponse, 0, len(results))
+	for _, r := range results {
+		items = append(items, employeeSearchResponse{employeeResponse: newEmployeeResponse(r.Employee, view), Rank: r.Rank, Snippet: r.Snippet})
+	}
+	return items
+}
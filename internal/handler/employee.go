@@ -2,8 +2,6 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
-	"go.mod/internal/model"
 	"net/http"
 	"strconv"
 )
@@ -11,50 +9,113 @@ import (
 // Получить одного сотрудника по ID
 func (h *Handlers) GetEmployee(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	ids, ok := r.URL.Query()["id"]
-	if !ok || len(ids[0]) < 1 {
-		http.Error(w, "Параметр 'id' отсутствует", http.StatusBadRequest)
+	id, ok := resourceID(w, r)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(ids[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Некорректный параметр 'id'", http.StatusBadRequest)
+	includeDeleted, ok := queryIncludeDeleted(w, r)
+	if !ok {
+		return
+	}
+
+	asOf, ok := queryAsOf(w, r)
+	if !ok {
+		return
+	}
+
+	// Запись на прошедшую дату не соответствует текущей версии, поэтому ETag для неё не выдаётся
+	if !asOf.IsZero() {
+		employee, err := h.service.Employees.GetAsOf(actorFromRequest(r), id, asOf)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, newEmployeeResponse(employee, h.fieldView(r)))
 		return
 	}
 
-	employee, err := h.db.GetEmployeeByID(id)
+	employee, err := h.service.Employees.Get(actorFromRequest(r), id, includeDeleted)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка: %v", err), http.StatusNotFound)
+		writeError(w, err)
+		return
+	}
+	resp := newEmployeeResponse(employee, h.fieldView(r))
+	if notModified(w, r, employeeETag(resp)) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(employee)
+	err = json.NewEncoder(w).Encode(resp)
 	if err != nil {
 		return
 	}
 }
 
-// Получить всех сотрудников
+// Получить список сотрудников: фильтры, сортировка и постраничный вывод
+//
+//	GET /employees?department=IT&status=active&hired_from=2024-01-01&sort=lastname,-hiredate&limit=50&cursor=...
+//	GET /employees?department_id=3&subdepartments=true — отдел вместе со всеми подотделами
+//	GET /employees?include_deleted=true — вместе с удалёнными (только для админов)
+//	GET /employees?as_of=2026-03-01 — должности, отделы и занятость на дату по кадровой истории
 func (h *Handlers) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query, err := parseEmployeeQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := h.service.Employees.List(actorFromRequest(r), query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if link := paginationLinks(r, query, page); link != "" {
+		w.Header().Add("Link", link)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(newEmployeePageResponse(page, h.fieldView(r)))
+	if err != nil {
+		return
+	}
+}
+
+// Поиск сотрудников по ФИО, должности, отделу, заметкам, телефону и почте
+//
+//	GET /employees/search?q=холов&limit=20
+func (h *Handlers) SearchEmployees(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	employees, err := h.db.GetAllEmployees()
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			invalidParam(w, "limit")
+			return
+		}
+	}
+
+	results, err := h.service.Employees.Search(actorFromRequest(r), r.URL.Query().Get("q"), limit)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка: %v", err), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(employees)
+	err = json.NewEncoder(w).Encode(map[string]interface{}{"items": newEmployeeSearchResponses(results, h.fieldView(r))})
 	if err != nil {
 		return
 	}
@@ -63,24 +124,24 @@ func (h *Handlers) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
 // Создать нового сотрудника
 func (h *Handlers) CreateEmployee(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	var employee model.Employee
-	if err := json.NewDecoder(r.Body).Decode(&employee); err != nil {
-		http.Error(w, "Неверный формат данных", http.StatusBadRequest)
+	var req employeeRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if err := h.db.CreateEmployee(employee); err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка создания сотрудника: %v", err), http.StatusInternalServerError)
+	id, err := h.service.Employees.Create(actorFromRequest(r), req.toModel())
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	err := json.NewEncoder(w).Encode(map[string]string{"message": "Сотрудник успешно создан"})
+	err = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "message": tr(w, "employee.created")})
 	if err != nil {
 		return
 	}
@@ -89,29 +150,54 @@ func (h *Handlers) CreateEmployee(w http.ResponseWriter, r *http.Request) {
 // Удалить сотрудника
 func (h *Handlers) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	ids, ok := r.URL.Query()["id"]
-	if !ok || len(ids[0]) < 1 {
-		http.Error(w, "Параметр 'id' отсутствует", http.StatusBadRequest)
+	id, ok := resourceID(w, r)
+	if !ok {
 		return
 	}
-	id, err := strconv.ParseInt(ids[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Некорректный параметр 'id'", http.StatusBadRequest)
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
 		return
 	}
 
-	if err := h.db.DeleteEmployee(id); err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка удаления сотрудника: %v", err), http.StatusInternalServerError)
+	if err := h.service.Employees.Delete(actorFromRequest(r), id, version); err != nil {
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(map[string]string{"message": "Сотрудник успешно удалён"})
+	err := json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "employee.deleted")})
+	if err != nil {
+		return
+	}
+}
+
+// Восстановить удалённого сотрудника
+//
+//	POST /restore_employee?id=5
+func (h *Handlers) RestoreEmployee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Employees.Restore(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "employee.restored")})
 	if err != nil {
 		return
 	}
@@ -120,35 +206,35 @@ func (h *Handlers) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 // Обновить данные сотрудника
 func (h *Handlers) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	var employee model.Employee
-	if err := json.NewDecoder(r.Body).Decode(&employee); err != nil {
-		http.Error(w, "Неверный формат данных", http.StatusBadRequest)
+	var req employeeRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	ids, ok := r.URL.Query()["id"]
-	if !ok || len(ids[0]) < 1 {
-		http.Error(w, "Параметр 'id' отсутствует", http.StatusBadRequest)
+	id, ok := resourceID(w, r)
+	if !ok {
 		return
 	}
-	id, err := strconv.ParseInt(ids[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Некорректный параметр 'id'", http.StatusBadRequest)
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
 		return
 	}
 
-	if err := h.db.UpdateEmployee(id, employee); err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка обновления сотрудника: %v", err), http.StatusInternalServerError)
+	updated, err := h.service.Employees.Update(actorFromRequest(r), id, version, req.toModel())
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
+	w.Header().Set("ETag", employeeETag(newEmployeeResponse(updated, h.fieldView(r))))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(map[string]string{"message": "Сотрудник успешно обновлён"})
+	err = json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "employee.updated")})
 	if err != nil {
 		return
 	}
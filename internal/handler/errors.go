@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gorilla/mux"
+	"go.mod/internal/messages"
+	"go.mod/internal/model"
+	"go.mod/internal/service"
+	"go.mod/pkg/i18n"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// writeError — единое преобразование доменных ошибок в problem+json на языке ответа.
+// Текст внутренних ошибок и ошибок базы клиенту не передаётся, он пишется в лог вместе с trace_id.
+func writeError(w http.ResponseWriter, err error) {
+	var (
+		validation *service.ValidationError
+		param      *paramError
+	)
+	switch {
+	case errors.As(err, &validation):
+		writeProblem(w, Problem{Status: http.StatusBadRequest, Code: "validation_failed", Detail: tr(w, "error.validation"), InvalidParams: invalidParams(w, validation.Fields)})
+	case errors.As(err, &param):
+		writeProblem(w, Problem{Status: http.StatusBadRequest, Code: "invalid_parameter", Detail: tr(w, "error.param_invalid", i18n.Args{"name": param.name})})
+	case errors.Is(err, service.ErrNotFound):
+		writeProblem(w, Problem{Status: http.StatusNotFound, Code: "not_found", Detail: errorDetail(w, err, "error.not_found")})
+	case errors.Is(err, service.ErrConflict):
+		writeProblem(w, Problem{Status: http.StatusConflict, Code: "conflict", Detail: errorDetail(w, err, "error.conflict")})
+	case errors.Is(err, service.ErrPreconditionFailed):
+		writeProblem(w, Problem{Status: http.StatusPreconditionFailed, Code: "precondition_failed", Detail: errorDetail(w, err, "error.precondition_failed")})
+	case errors.Is(err, service.ErrForbidden):
+		writeProblem(w, Problem{Status: http.StatusForbidden, Code: "forbidden", Detail: errorDetail(w, err, "error.forbidden")})
+	case errors.Is(err, service.ErrInvalidCredentials):
+		writeProblem(w, Problem{Status: http.StatusUnauthorized, Code: "invalid_credentials", Detail: tr(w, "error.invalid_credentials")})
+	case errors.Is(err, service.ErrInvalidToken):
+		writeProblem(w, Problem{Status: http.StatusUnauthorized, Code: "invalid_token", Detail: tr(w, "error.invalid_token")})
+	case errors.Is(err, service.ErrTooManyAttempts):
+		var limit *service.LimitError
+		if errors.As(err, &limit) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(limit.RetryAfter.Seconds())))
+		}
+		writeProblem(w, Problem{Status: http.StatusTooManyRequests, Code: "too_many_attempts", Detail: errorDetail(w, err, "error.login_rate_limited")})
+	case errors.Is(err, service.ErrUnavailable):
+		logError(w, "база данных недоступна", err)
+		w.Header().Set("Retry-After", "5")
+		writeProblem(w, Problem{Status: http.StatusServiceUnavailable, Code: "service_unavailable", Detail: tr(w, "error.unavailable")})
+	default:
+		logError(w, "внутренняя ошибка", err)
+		writeProblem(w, Problem{Status: http.StatusInternalServerError, Code: "internal_error", Detail: tr(w, "error.internal")})
+	}
+}
+
+// errorDetail — сообщение доменной ошибки на языке ответа, а если его нет — общее сообщение key
+func errorDetail(w http.ResponseWriter, err error, key string) string {
+	var localized service.Localized
+	if errors.As(err, &localized) && localized.Text().Key != "" {
+		return translate(w, localized.Text())
+	}
+	return tr(w, key)
+}
+
+// paramError — некорректный параметр строки запроса
+type paramError struct {
+	name string
+}
+
+func (e *paramError) Error() string {
+	return messages.Default(i18n.T("error.param_invalid", i18n.Args{"name": e.name}))
+}
+
+// invalidParam — ответ 400 о некорректном параметре строки запроса
+func invalidParam(w http.ResponseWriter, name string) {
+	writeError(w, &paramError{name: name})
+}
+
+// logError — ошибка в лог с trace_id запроса; исходная ошибка драйвера, если есть, — отдельно
+func logError(w http.ResponseWriter, message string, err error) {
+	traceID := w.Header().Get("X-Request-ID")
+	var caused interface{ Cause() error }
+	if errors.As(err, &caused) {
+		log.Printf("[%s] %s: %v (причина: %v)", traceID, message, err, caused.Cause())
+		return
+	}
+	log.Printf("[%s] %s: %v", traceID, message, err)
+}
+
+// actorFromRequest — пользователь, которого JWTMiddleware сохранил в заголовках запроса
+func actorFromRequest(r *http.Request) service.Actor {
+	var permissions []string
+	for _, p := range strings.Split(r.Header.Get("X-Permissions"), ",") {
+		if p != "" {
+			permissions = append(permissions, p)
+		}
+	}
+	var roles []string
+	for _, role := range strings.Split(r.Header.Get("X-Roles"), ",") {
+		if role != "" {
+			roles = append(roles, role)
+		}
+	}
+	var departmentID *int
+	if id, err := strconv.Atoi(r.Header.Get("X-Department")); err == nil {
+		departmentID = &id
+	}
+	return service.Actor{
+		Username:     r.Header.Get("X-User"),
+		Roles:        roles,
+		Permissions:  permissions,
+		DepartmentID: departmentID,
+		RequestID:    r.Header.Get("X-Request-ID"),
+	}
+}
+
+// fieldView — видимость полей сотрудника для пользователя запроса (скрытые поля не попадают в ответ)
+func (h *Handlers) fieldView(r *http.Request) model.FieldView {
+	return h.service.Employees.FieldView(actorFromRequest(r))
+}
+
+// resourceID — id записи из пути (/api/v1/employees/5) или из строки запроса (?id=5);
+// при ошибке ответ уже отправлен
+func resourceID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	raw, ok := mux.Vars(r)["id"]
+	if !ok {
+		ids, found := r.URL.Query()["id"]
+		if !found || len(ids[0]) < 1 {
+			httpError(w, "error.param_missing", http.StatusBadRequest, i18n.Args{"name": "id"})
+			return 0, false
+		}
+		raw = ids[0]
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		invalidParam(w, "id")
+		return 0, false
+	}
+	return id, true
+}
+
+// queryIncludeDeleted — параметр ?include_deleted=true (показывать мягко удалённые записи)
+func queryIncludeDeleted(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	v := r.URL.Query().Get("include_deleted")
+	if v == "" {
+		return false, true
+	}
+	includeDeleted, err := strconv.ParseBool(v)
+	if err != nil {
+		invalidParam(w, "include_deleted")
+		return false, false
+	}
+	return includeDeleted, true
+}
+
+// queryAsOf — параметр ?as_of=2026-03-01 (данные на дату по кадровой истории); нулевая дата, если не указан
+func queryAsOf(w http.ResponseWriter, r *http.Request) (model.Date, bool) {
+	asOf, err := model.ParseDate(r.URL.Query().Get("as_of"))
+	if err != nil {
+		invalidParam(w, "as_of")
+		return model.Date{}, false
+	}
+	return asOf, true
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Условные запросы: ETag — версия записи с хешем представления, If-None-Match для чтения, If-Match для изменения
+
+// representationETag — ETag записи с версией version, ответ body о которой зависит не только от версии:
+// к версии добавляется хеш ответа. Так ETag сотрудника меняется при переименовании его отдела
+// и различается у пользователей, которым видны разные поля. If-Match сравнивает только версию.
+func representationETag(version int, body any) string {
+	data, _ := json.Marshal(body)
+	sum := sha256.Sum256(data)
+	return `"` + strconv.Itoa(version) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// employeeETag — ETag сотрудника в том виде, в каком его получает пользователь
+func employeeETag(e employeeResponse) string {
+	return representationETag(e.Version, e)
+}
+
+// userETag — ETag пользователя: состояние входа (failed_attempts, locked_until) меняется без новой версии,
+// чтобы вход не мешал изменениям с If-Match, поэтому одной версии недостаточно
+func userETag(u userResponse) string {
+	return representationETag(u.Version, u)
+}
+
+// notModified — отвечает 304, если клиент уже получил эту версию записи с ETag tag (If-None-Match).
+// Иначе выставляет ETag и возвращает false.
+func notModified(w http.ResponseWriter, r *http.Request, tag string) bool {
+	w.Header().Set("ETag", tag)
+
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	// Для If-None-Match используется слабое сравнение: префикс W/ не учитывается
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion — версия записи из обязательного заголовка If-Match (из ETag "3-<хеш>" или просто "3"); "*" — любая версия (0).
+// Без заголовка отвечает 428, при нераспознанном значении — 412; в этих случаях возвращает false.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		httpError(w, "error.if_match_required", http.StatusPreconditionRequired)
+		return 0, false
+	}
+	if header == "*" {
+		return 0, true
+	}
+
+	// Слабый ETag не подходит для If-Match (требуется строгое сравнение)
+	value, _, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(header, `"`), `"`), "-")
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		httpError(w, "error.if_match_mismatch", http.StatusPreconditionFailed)
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"go.mod/internal/model"
+	"net/http"
+)
+
+// Тело запроса на кадровое действие; не указанные должность и отдел остаются прежними
+type assignmentRequest struct {
+	Action        string     `json:"action"`
+	EffectiveDate model.Date `json:"effective_date"`
+	Position      string     `json:"position"`
+	DepartmentId  *int       `json:"department_id"`
+	Reason        string     `json:"reason"`
+}
+
+// Кадровая история сотрудника: приёмы, переводы, повышения, увольнения, в том числе с будущей датой
+//
+//	GET /api/v1/employees/5/assignments
+func (h *Handlers) GetEmployeeAssignments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := h.service.Employees.JobHistory(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": history})
+}
+
+// Добавить кадровое действие. С датой не позже сегодняшней оно сразу меняет запись сотрудника,
+// с будущей — вступает в силу автоматически в этот день.
+//
+//	POST /api/v1/employees/5/assignments
+//	{"action": "transfer", "effective_date": "2026-03-01", "department_id": 4, "reason": "Реорганизация"}
+func (h *Handlers) CreateEmployeeAssignment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req assignmentRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	created, err := h.service.Employees.AddAssignment(actorFromRequest(r), id, model.JobAssignment{
+		Action:        req.Action,
+		EffectiveDate: req.EffectiveDate,
+		Position:      req.Position,
+		DepartmentId:  req.DepartmentId,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"go.mod/internal/messages"
+	"go.mod/pkg/i18n"
+	"net/http"
+)
+
+// LocaleMiddleware — язык ответа по заголовку Accept-Language (по умолчанию русский).
+// Выбранный язык возвращается в Content-Language, и по нему же переводятся все сообщения ответа;
+// JWTMiddleware заменяет его языком из профиля пользователя, если тот выбран.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Language", messages.Bundle.Negotiate(r.Header.Get("Accept-Language")))
+		w.Header().Add("Vary", "Accept-Language")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setLocale — язык из профиля пользователя; неподдерживаемый или пустой не меняет выбранный по заголовку
+func setLocale(w http.ResponseWriter, lang string) {
+	if lang != "" && messages.Bundle.Supports(lang) {
+		w.Header().Set("Content-Language", lang)
+	}
+}
+
+// translate — сообщение на языке ответа
+func translate(w http.ResponseWriter, text i18n.Text) string {
+	return messages.Translate(w.Header().Get("Content-Language"), text)
+}
+
+// tr — сообщение каталога по ключу на языке ответа
+func tr(w http.ResponseWriter, key string, args ...i18n.Args) string {
+	return translate(w, i18n.T(key, args...))
+}
@@ -2,97 +2,90 @@ package handler
 
 import (
 	"encoding/json"
-	"github.com/golang-jwt/jwt/v5"
-	"go.mod/internal/config"
 	"go.mod/internal/model"
+	"net"
 	"net/http"
 	"strings"
-	"time"
 )
 
 // LoginHandler — обработчик входа пользователя
 
 func (h *Handlers) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
 	// Считываем данные из тела запроса
 	var creds model.Credentials
 	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		httpError(w, "error.invalid_body", http.StatusBadRequest)
 		return
 	}
 
-	// Проверка username и password в базе данных
-	var role string
-	err := h.db.Connection.QueryRow(
-		"SELECT role FROM users WHERE username=$1 AND password=$2",
-		creds.Username,
-		creds.Password,
-	).Scan(&role)
+	// Проверка логина и пароля и выдача токенов; лимит попыток считается и по адресу клиента
+	tokens, err := h.service.Auth.Login(creds.Username, creds.Password, clientIP(r), r.Header.Get("X-Request-ID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Возвращаем токены в ответе
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
 
+// clientIP — адрес клиента из соединения. X-Forwarded-For не используется: его может подделать сам клиент,
+// чтобы обойти лимит попыток входа.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RefreshHandler — обмен refresh-токена на новую пару токенов (ротация)
+func (h *Handlers) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Создание JWT-токена
-	expirationTime := time.Now().Add(10 * time.Minute)
-	claims := &model.Claims{
-		Username: creds.Username,
-		Role:     role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-		},
+	var req model.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		httpError(w, "error.invalid_body", http.StatusBadRequest)
+		return
 	}
 
-	// Подпись токена секретным ключом
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(config.JWTKey)
+	tokens, err := h.service.Auth.Refresh(req.RefreshToken)
 	if err != nil {
-		http.Error(w, "Could not create token", http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	// Возвращаем токен в ответе
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"token": tokenString})
+	json.NewEncoder(w).Encode(tokens)
 }
 
-// ========================== Проверка роли администратора ==========================
-
-// IsAdmin — middleware для проверки, что пользователь является администратором.
-func (h *Handlers) IsAdmin(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Извлекаем токен из заголовка Authorization
-		tokenHeader := r.Header.Get("Authorization")
-		if tokenHeader == "" {
-			http.Error(w, "Missing token", http.StatusUnauthorized)
-			return
-		}
-
-		tokenString := strings.TrimPrefix(tokenHeader, "Bearer ")
-
-		// Разбираем токен и проверяем его подпись
-		claims := &model.Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return config.JWTKey, nil
-		})
-
-		if err != nil || !token.Valid {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			return
-		}
+// LogoutHandler — выход: отзывает семейство refresh-токена и текущий access-токен
+func (h *Handlers) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-		// Проверка роли пользователя
-		if claims.Role != "admin" {
-			http.Error(w, "Forbidden: Admins only", http.StatusForbidden)
-			return
-		}
+	var req model.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		httpError(w, "error.invalid_body", http.StatusBadRequest)
+		return
+	}
 
-		// Всё хорошо — передаём управление следующему обработчику
-		next(w, r)
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if err := h.service.Auth.Logout(req.RefreshToken, accessToken); err != nil {
+		writeError(w, err)
+		return
 	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
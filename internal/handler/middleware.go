@@ -1,40 +1,114 @@
 package handler
 
 import (
-	"github.com/golang-jwt/jwt/v5"
-	"go.mod/internal/config"
-	"go.mod/internal/model"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/gorilla/mux"
+	"go.mod/pkg/i18n"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Допустимый идентификатор запроса, переданный клиентом или прокси
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware — каждому запросу свой X-Request-ID (переданный клиентом или новый);
+// он возвращается в ответе и попадает в журнал аудита
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if !requestIDPattern.MatchString(id) {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+			r.Header.Set("X-Request-ID", id)
+		}
+		w.Header().Set("X-Request-ID", id)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Дата, с которой старые маршруты без /api/v1 считаются устаревшими
+var legacyDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// Deprecated — помечает устаревший маршрут заголовком Deprecation (RFC 9745)
+// и ссылкой на замену в /api/v1; сам маршрут продолжает работать как раньше.
+// {id} в адресе замены подставляется из запроса, без id ссылка ведёт на коллекцию.
+func Deprecated(successor string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		link := successor
+		if i := strings.Index(link, "/{id}"); i >= 0 {
+			id, ok := mux.Vars(r)["id"]
+			if !ok {
+				id = r.URL.Query().Get("id")
+			}
+			if _, err := strconv.ParseInt(id, 10, 64); err == nil {
+				link = strings.Replace(link, "{id}", id, 1)
+			} else {
+				link = link[:i]
+			}
+		}
+
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(legacyDeprecatedAt.Unix(), 10))
+		w.Header().Add("Link", "<"+link+">; rel=\"successor-version\"")
+
+		next(w, r)
+	}
+}
+
 // JWTMiddleware — промежуточный обработчик для проверки JWT-токена
 func (h *Handlers) JWTMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Извлекаем токен из заголовка Authorization
 		tokenStr := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
 		if tokenStr == "" {
-			http.Error(w, "Missing token", http.StatusUnauthorized)
+			httpError(w, "error.token_missing", http.StatusUnauthorized)
 			return
 		}
 
-		// Структура для хранения данных токена
-		claims := &model.Claims{}
+		// Парсим и проверяем токен (подпись, срок действия, список отозванных)
+		claims, err := h.service.Auth.ParseToken(tokenStr)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
 
-		// Парсим и проверяем токен
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-			return config.JWTKey, nil
-		})
-		if err != nil || !token.Valid {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+		// Разрешения читаются из ролей пользователя при каждом запросе: изменение ролей действует сразу,
+		// а не после перевыпуска токена
+		permissions, err := h.service.Auth.Permissions(claims.Username)
+		if err != nil {
+			writeError(w, err)
 			return
 		}
 
-		// Сохраняем имя пользователя и роль в заголовок запроса (опционально)
+		// Сохраняем имя пользователя, роли, разрешения и отдел в заголовки запроса (присланные клиентом перезаписываются).
+		// Роли берутся из токена: при их изменении токены пользователя отзываются.
 		r.Header.Set("X-User", claims.Username)
-		r.Header.Set("X-Role", claims.Role)
+		r.Header.Set("X-Roles", strings.Join(claims.Roles, ","))
+		r.Header.Set("X-Permissions", strings.Join(permissions, ","))
+		r.Header.Del("X-Department")
+		if claims.DepartmentId != nil {
+			r.Header.Set("X-Department", strconv.Itoa(*claims.DepartmentId))
+		}
+		setLocale(w, claims.Locale)
 
 		// Вызываем следующий обработчик
 		next(w, r)
 	}
 }
+
+// RequirePermission — middleware для проверки разрешения у пользователя.
+// Используется внутри JWTMiddleware, который заполняет разрешения из ролей пользователя.
+func (h *Handlers) RequirePermission(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !actorFromRequest(r).Can(permission) {
+			httpError(w, "error.permission_denied", http.StatusForbidden, i18n.Args{"permission": permission})
+			return
+		}
+		next(w, r)
+	}
+}
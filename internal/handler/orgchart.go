@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"go.mod/internal/orgchart"
+	"net/http"
+	"strconv"
+)
+
+// Непосредственные подчинённые сотрудника
+//
+//	GET /employee/reports?id=1
+func (h *Handlers) GetDirectReports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	reports, err := h.service.Employees.DirectReports(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(newEmployeeResponses(reports, h.fieldView(r)))
+	if err != nil {
+		return
+	}
+}
+
+// Цепочка руководителей сотрудника: от непосредственного до верхнего
+//
+//	GET /employee/managers?id=5
+func (h *Handlers) GetManagerChain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	chain, err := h.service.Employees.ManagerChain(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(newEmployeeResponses(chain, h.fieldView(r)))
+	if err != nil {
+		return
+	}
+}
+
+// Сотрудник и все его подчинённые деревом
+//
+//	GET /employee/subordinates?id=1
+func (h *Handlers) GetSubordinates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	tree, err := h.service.Employees.Subordinates(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(tree)
+	if err != nil {
+		return
+	}
+}
+
+// Оргструктура целиком или начиная с сотрудника root в формате json, dot или svg
+//
+//	GET /orgchart?format=svg&root=1
+func (h *Handlers) GetOrgChart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var rootID int64
+	if value := r.URL.Query().Get("root"); value != "" {
+		var err error
+		if rootID, err = strconv.ParseInt(value, 10, 64); err != nil || rootID <= 0 {
+			invalidParam(w, "root")
+			return
+		}
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "dot" && format != "svg" {
+		httpError(w, "error.orgchart_format", http.StatusBadRequest)
+		return
+	}
+
+	roots, err := h.service.Employees.OrgChart(actorFromRequest(r), rootID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch format {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_, _ = w.Write([]byte(orgchart.DOT(roots)))
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte(orgchart.SVG(roots)))
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(roots)
+		if err != nil {
+			return
+		}
+	}
+}
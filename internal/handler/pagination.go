@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// parseEmployeeQuery — параметры списка сотрудников из строки запроса
+func parseEmployeeQuery(r *http.Request) (model.EmployeeQuery, error) {
+	values := r.URL.Query()
+	query := model.EmployeeQuery{
+		Department: values["department"],
+		Position:   values["position"],
+		Status:     values["status"],
+		HiredFrom:  values.Get("hired_from"),
+		HiredTo:    values.Get("hired_to"),
+		Cursor:     values.Get("cursor"),
+		Sort:       parseSort(values.Get("sort")),
+	}
+
+	var err error
+	if v := values.Get("department_id"); v != "" {
+		if query.DepartmentID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return query, &paramError{name: "department_id"}
+		}
+	}
+	if v := values.Get("subdepartments"); v != "" {
+		if query.Subdepartments, err = strconv.ParseBool(v); err != nil {
+			return query, &paramError{name: "subdepartments"}
+		}
+	}
+	if v := values.Get("include_deleted"); v != "" {
+		if query.IncludeDeleted, err = strconv.ParseBool(v); err != nil {
+			return query, &paramError{name: "include_deleted"}
+		}
+	}
+	if v := values.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			return query, &paramError{name: "limit"}
+		}
+	}
+	if v := values.Get("offset"); v != "" {
+		if query.Offset, err = strconv.Atoi(v); err != nil {
+			return query, &paramError{name: "offset"}
+		}
+	}
+	if v := values.Get("as_of"); v != "" {
+		if query.AsOf, err = model.ParseDate(v); err != nil {
+			return query, &paramError{name: "as_of"}
+		}
+	}
+	return query, nil
+}
+
+// parseSort — "lastname,-hiredate": минус перед полем означает сортировку по убыванию
+func parseSort(value string) []model.SortField {
+	var sort []model.SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field := model.SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		sort = append(sort, field)
+	}
+	return sort
+}
+
+// paginationLinks — заголовок Link (RFC 8288) со ссылками на соседние страницы.
+// При выборке по смещению ссылки строятся через offset, иначе — через курсор.
+func paginationLinks(r *http.Request, query model.EmployeeQuery, page model.EmployeePage) string {
+	byOffset := r.URL.Query().Has("offset")
+	var links []string
+
+	link := func(rel string, set map[string]string) {
+		values := r.URL.Query()
+		values.Del("cursor")
+		values.Del("offset")
+		values.Set("limit", strconv.Itoa(page.Limit))
+		for k, v := range set {
+			values.Set(k, v)
+		}
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, values.Encode(), rel))
+	}
+
+	link("first", nil)
+	if page.NextCursor != "" {
+		if byOffset {
+			link("next", map[string]string{"offset": strconv.Itoa(query.Offset + page.Limit)})
+		} else {
+			link("next", map[string]string{"cursor": page.NextCursor})
+		}
+	}
+	if byOffset && query.Offset > 0 {
+		prev := query.Offset - page.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		link("prev", map[string]string{"offset": strconv.Itoa(prev)})
+	}
+
+	return strings.Join(links, ", ")
+}
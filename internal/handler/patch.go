@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"go.mod/internal/service"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Максимальный размер тела PATCH-запроса
+const maxPatchSize = 1 << 20
+
+// Форматы патча по Content-Type
+var patchFormats = map[string]string{
+	"application/merge-patch+json": service.PatchMerge,
+	"application/json-patch+json":  service.PatchJSON,
+}
+
+// Частичное обновление сотрудника. Версия записи обязательна в If-Match, в ответе — обновлённая запись.
+//
+//	PATCH /employees/5
+//	Content-Type: application/merge-patch+json
+//	{"phonenumber": "+992 900 00 00 00", "notes": null}
+//
+//	PATCH /employees/5
+//	Content-Type: application/json-patch+json
+//	[{"op": "test", "path": "/status", "value": "active"}, {"op": "replace", "path": "/position", "value": "Ведущий инженер"}]
+func (h *Handlers) PatchEmployee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format, ok := patchFormats[mediaType]
+	if !ok {
+		w.Header().Set("Accept-Patch", "application/merge-patch+json, application/json-patch+json")
+		httpError(w, "error.patch_media_type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		httpError(w, "error.invalid_body", http.StatusBadRequest)
+		return
+	}
+
+	employee, err := h.service.Employees.Patch(actorFromRequest(r), id, version, format, patch)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := newEmployeeResponse(employee, h.fieldView(r))
+	w.Header().Set("ETag", employeeETag(resp))
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		return
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"go.mod/pkg/i18n"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Problem — описание ошибки в формате application/problem+json (RFC 7807).
+// Code — стабильный машиночитаемый код ошибки, TraceID — X-Request-ID запроса (по нему ошибку можно найти в логе).
+// Title, Detail и причины в InvalidParams — на языке ответа (Content-Language).
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Code          string         `json:"code"`
+	TraceID       string         `json:"trace_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam — ошибка в конкретном поле или параметре запроса
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Коды ошибок по статусу ответа (для ошибок без более точного кода)
+var problemCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusPreconditionRequired:  "precondition_required",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal_error",
+	http.StatusServiceUnavailable:    "service_unavailable",
+}
+
+// httpError — ответ об ошибке с кодом по статусу; detail — ключ сообщения из каталога
+func httpError(w http.ResponseWriter, key string, status int, args ...i18n.Args) {
+	writeProblem(w, Problem{Status: status, Code: problemCodes[status], Detail: tr(w, key, args...)})
+}
+
+// writeProblem — отправляет problem+json; недостающие type, title и trace_id заполняются сами
+func writeProblem(w http.ResponseWriter, p Problem) {
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	if p.Title == "" {
+		p.Title = tr(w, "status."+strconv.Itoa(p.Status))
+	}
+	if p.Code == "" {
+		p.Code = "error"
+	}
+	p.TraceID = w.Header().Get("X-Request-ID")
+
+	// Заголовки, выставленные для успешного ответа, к ошибке не относятся
+	w.Header().Del("ETag")
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	err := json.NewEncoder(w).Encode(p)
+	if err != nil {
+		return
+	}
+}
+
+// invalidParams — ошибки полей на языке ответа в порядке имён (порядок не зависит от обхода map)
+func invalidParams(w http.ResponseWriter, fields map[string]i18n.Text) []InvalidParam {
+	params := make([]InvalidParam, 0, len(fields))
+	for name, reason := range fields {
+		params = append(params, InvalidParam{Name: name, Reason: translate(w, reason)})
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
+}
+
+// notFoundHandler и methodNotAllowedHandler — ответы роутера для неизвестных путей и методов
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	httpError(w, "error.route_not_found", http.StatusNotFound, i18n.Args{"path": r.URL.Path})
+}
+
+func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+)
+
+// Роли и разрешения
+
+// Список ролей с их разрешениями
+//
+//	GET /api/v1/roles
+func (h *Handlers) GetRoles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	roles, err := h.service.Roles.List(actorFromRequest(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, roles)
+}
+
+// Получить роль по ID
+//
+//	GET /api/v1/roles/3
+func (h *Handlers) GetRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	role, err := h.service.Roles.Get(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, role)
+}
+
+// Создать роль
+//
+//	POST /api/v1/roles -> 201, Location: /api/v1/roles/7
+func (h *Handlers) CreateRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req roleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	actor := actorFromRequest(r)
+	id, err := h.service.Roles.Create(actor, req.toModel())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	created, err := h.service.Roles.Get(actor, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", location("roles", id))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// Заменить описание и разрешения роли; имя роли изменить нельзя
+//
+//	PUT /api/v1/roles/7
+func (h *Handlers) ReplaceRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req roleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	updated, err := h.service.Roles.Update(actorFromRequest(r), id, req.toModel())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// Удалить роль; встроенную или назначенную пользователям удалить нельзя (409)
+//
+//	DELETE /api/v1/roles/7 -> 204
+func (h *Handlers) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Roles.Delete(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Список всех разрешений, которые можно включить в роль
+//
+//	GET /api/v1/permissions
+func (h *Handlers) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	permissions, err := h.service.Roles.Permissions(actorFromRequest(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, permissions)
+}
+
+// Роли пользователя
+//
+//	GET /api/v1/users/7/roles -> {"roles": ["hr_manager"]}
+func (h *Handlers) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.Users.Get(actorFromRequest(r), id, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userRolesRequest{Roles: newUserResponse(user).Roles})
+}
+
+// Назначить пользователю роли (заменяет прежние); выданные ему токены отзываются
+//
+//	PUT /api/v1/users/7/roles {"roles": ["hr_manager", "auditor"]}
+func (h *Handlers) SetUserRoles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req userRolesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	updated, err := h.service.Users.SetRoles(actorFromRequest(r), id, req.Roles)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := newUserResponse(updated)
+	w.Header().Set("ETag", userETag(resp))
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -2,47 +2,141 @@ package handler
 
 import (
 	"github.com/gorilla/mux"
-	"go.mod/internal/database"
+	"go.mod/internal/config"
+	"go.mod/internal/model"
 	"go.mod/internal/service"
 	"net/http"
 )
 
 // Handlers — структура для всех обработчиков
 type Handlers struct {
-	db      *database.Database // Работа с базой данных
-	service *service.Service   // Логика приложения (если используется)
+	service *service.Service // Бизнес-логика приложения
+	cfg     *config.Config   // Конфигурация приложения
 }
 
 // NewHandler — конструктор нового экземпляра Handlers
-func NewHandler(s *service.Service, db *database.Database) *Handlers {
+func NewHandler(s *service.Service, cfg *config.Config) *Handlers {
 	return &Handlers{
 		service: s,
-		db:      db,
+		cfg:     cfg,
 	}
 }
 
 // InitRoutes — инициализация всех маршрутов (роутов) приложения
 func (h *Handlers) InitRoutes() *mux.Router {
 	router := mux.NewRouter()
+	router.Use(RequestIDMiddleware, LocaleMiddleware)
+	router.NotFoundHandler = RequestIDMiddleware(LocaleMiddleware(http.HandlerFunc(notFoundHandler)))
+	router.MethodNotAllowedHandler = RequestIDMiddleware(LocaleMiddleware(http.HandlerFunc(methodNotAllowedHandler)))
+
+	h.initRoutesV1(router)
+
+	// Старые маршруты продолжают работать, но помечены как устаревшие (заголовки Deprecation и Link)
 
 	// Авторизация
-	router.HandleFunc("/login", h.LoginHandler).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/login", Deprecated(apiV1+"/auth/login", h.LoginHandler)).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/refresh", Deprecated(apiV1+"/auth/refresh", h.RefreshHandler)).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/logout", Deprecated(apiV1+"/auth/logout", h.LogoutHandler)).Methods(http.MethodPost, http.MethodOptions)
+
+	// Сотрудники
+	router.HandleFunc("/employee", Deprecated(apiV1+"/employees/{id}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetEmployee)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/employees", Deprecated(apiV1+"/employees", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetAllEmployees)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/employees/search", Deprecated(apiV1+"/employees/search", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.SearchEmployees)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/employees/{id:[0-9]+}/history", Deprecated(apiV1+"/employees/{id}/history", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesHistory, h.GetEmployeeHistory)))).Methods(http.MethodGet, http.MethodOptions)
+
+	// Подчинённость и оргструктура
+	router.HandleFunc("/employee/reports", Deprecated(apiV1+"/employees/{id}/reports", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetDirectReports)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/employee/managers", Deprecated(apiV1+"/employees/{id}/managers", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetManagerChain)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/employee/subordinates", Deprecated(apiV1+"/employees/{id}/subordinates", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetSubordinates)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/orgchart", Deprecated(apiV1+"/orgchart", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetOrgChart)))).Methods(http.MethodGet, http.MethodOptions)
 
-	// Открытые маршруты для пользователей
-	router.HandleFunc("/employee", h.JWTMiddleware(h.GetEmployee)).Methods(http.MethodGet, http.MethodOptions)
-	router.HandleFunc("/employees", h.JWTMiddleware(h.GetAllEmployees)).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/add_employee", Deprecated(apiV1+"/employees", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesCreate, h.CreateEmployee)))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/delete_employee", Deprecated(apiV1+"/employees/{id}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesDelete, h.DeleteEmployee)))).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/update_employee", Deprecated(apiV1+"/employees/{id}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesUpdate, h.UpdateEmployee)))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/employees/{id:[0-9]+}", Deprecated(apiV1+"/employees/{id}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesUpdate, h.PatchEmployee)))).Methods(http.MethodPatch, http.MethodOptions)
+	router.HandleFunc("/restore_employee", Deprecated(apiV1+"/employees/{id}/restore", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesDelete, h.RestoreEmployee)))).Methods(http.MethodPost, http.MethodOptions)
 
-	// Защищённые маршруты (только для админов)
-	router.HandleFunc("/add_employee", h.JWTMiddleware(h.IsAdmin(h.CreateEmployee))).Methods(http.MethodPost, http.MethodOptions)
-	router.HandleFunc("/delete_employee", h.JWTMiddleware(h.IsAdmin(h.DeleteEmployee))).Methods(http.MethodDelete, http.MethodOptions)
-	router.HandleFunc("/update_employee", h.JWTMiddleware(h.IsAdmin(h.UpdateEmployee))).Methods(http.MethodPost, http.MethodOptions)
+	// Отделы
+	router.HandleFunc("/department", Deprecated(apiV1+"/departments/{id}", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsRead, h.GetDepartment)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/departments", Deprecated(apiV1+"/departments", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsRead, h.GetAllDepartments)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/add_department", Deprecated(apiV1+"/departments", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsWrite, h.CreateDepartment)))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/update_department", Deprecated(apiV1+"/departments/{id}", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsWrite, h.UpdateDepartment)))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/delete_department", Deprecated(apiV1+"/departments/{id}", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsWrite, h.DeleteDepartment)))).Methods(http.MethodDelete, http.MethodOptions)
 
-	// Защищённые маршруты (только для админов)
-	router.HandleFunc("/user", h.JWTMiddleware(h.IsAdmin(h.GetUser))).Methods(http.MethodGet, http.MethodOptions)
-	router.HandleFunc("/users", h.JWTMiddleware(h.IsAdmin(h.GetAllUsers))).Methods(http.MethodGet, http.MethodOptions)
-	router.HandleFunc("/add_user", h.JWTMiddleware(h.IsAdmin(h.CreateUser))).Methods(http.MethodPost, http.MethodOptions)
-	router.HandleFunc("/delete_user", h.JWTMiddleware(h.IsAdmin(h.DeleteUser))).Methods(http.MethodDelete, http.MethodOptions)
-	router.HandleFunc("/update_user", h.JWTMiddleware(h.IsAdmin(h.UpdateUser))).Methods(http.MethodPut, http.MethodOptions)
+	// Пользователи
+	router.HandleFunc("/user", Deprecated(apiV1+"/users/{id}", h.JWTMiddleware(h.RequirePermission(model.PermUsersRead, h.GetUser)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/users", Deprecated(apiV1+"/users", h.JWTMiddleware(h.RequirePermission(model.PermUsersRead, h.GetAllUsers)))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/add_user", Deprecated(apiV1+"/users", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.CreateUser)))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc("/delete_user", Deprecated(apiV1+"/users/{id}", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.DeleteUser)))).Methods(http.MethodDelete, http.MethodOptions)
+	router.HandleFunc("/update_user", Deprecated(apiV1+"/users/{id}", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.UpdateUser)))).Methods(http.MethodPut, http.MethodOptions)
+	router.HandleFunc("/restore_user", Deprecated(apiV1+"/users/{id}/restore", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.RestoreUser)))).Methods(http.MethodPost, http.MethodOptions)
+
+	// Журнал аудита
+	router.HandleFunc("/audit", Deprecated(apiV1+"/audit", h.JWTMiddleware(h.RequirePermission(model.PermAuditRead, h.GetAudit)))).Methods(http.MethodGet, http.MethodOptions)
+
+	// Окончательное удаление записей с истёкшим сроком хранения
+	router.HandleFunc("/purge", Deprecated(apiV1+"/purge", h.JWTMiddleware(h.RequirePermission(model.PermDataPurge, h.Purge)))).Methods(http.MethodPost, http.MethodOptions)
 
 	return router
 }
+
+// initRoutesV1 — маршруты /api/v1: ресурсы с id в пути, действие задаётся методом запроса.
+// Регистрируются в основном роутере, а не в PathPrefix-подроутере: подроутер отвечает 404 вместо 405
+// на неподдерживаемый метод.
+func (h *Handlers) initRoutesV1(router *mux.Router) {
+	// Авторизация
+	router.HandleFunc(apiV1+"/auth/login", h.LoginHandler).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc(apiV1+"/auth/refresh", h.RefreshHandler).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc(apiV1+"/auth/logout", h.LogoutHandler).Methods(http.MethodPost, http.MethodOptions)
+
+	// Сотрудники. Каждый маршрут требует своего разрешения (RequirePermission), права на запись проверяются и в сервисах.
+	router.HandleFunc(apiV1+"/employees", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetAllEmployees))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesCreate, h.CreateEmployeeV1))).Methods(http.MethodPost)
+	router.HandleFunc(apiV1+"/employees/search", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.SearchEmployees))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetEmployee))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesUpdate, h.ReplaceEmployeeV1))).Methods(http.MethodPut)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesUpdate, h.PatchEmployee))).Methods(http.MethodPatch)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesDelete, h.DeleteEmployeeV1))).Methods(http.MethodDelete)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/restore", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesDelete, h.RestoreEmployeeV1))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/history", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesHistory, h.GetEmployeeHistory))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/status", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesUpdate, h.ChangeEmployeeStatusV1))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/status-history", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesHistory, h.GetEmployeeStatusHistory))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/assignments", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesHistory, h.GetEmployeeAssignments))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/assignments", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesUpdate, h.CreateEmployeeAssignment))).Methods(http.MethodPost)
+
+	// Подчинённость и оргструктура
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/reports", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetDirectReports))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/managers", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetManagerChain))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/employees/{id:[0-9]+}/subordinates", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetSubordinates))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/orgchart", h.JWTMiddleware(h.RequirePermission(model.PermEmployeesRead, h.GetOrgChart))).Methods(http.MethodGet, http.MethodOptions)
+
+	// Отделы
+	router.HandleFunc(apiV1+"/departments", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsRead, h.GetAllDepartments))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/departments", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsWrite, h.CreateDepartmentV1))).Methods(http.MethodPost)
+	router.HandleFunc(apiV1+"/departments/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsRead, h.GetDepartment))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/departments/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsWrite, h.ReplaceDepartmentV1))).Methods(http.MethodPut)
+	router.HandleFunc(apiV1+"/departments/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermDepartmentsWrite, h.DeleteDepartmentV1))).Methods(http.MethodDelete)
+
+	// Пользователи и их роли
+	router.HandleFunc(apiV1+"/users", h.JWTMiddleware(h.RequirePermission(model.PermUsersRead, h.GetAllUsers))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/users", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.CreateUserV1))).Methods(http.MethodPost)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermUsersRead, h.GetUser))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.ReplaceUserV1))).Methods(http.MethodPut)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.DeleteUserV1))).Methods(http.MethodDelete)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}/restore", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.RestoreUserV1))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}/unlock", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.UnlockUserV1))).Methods(http.MethodPost, http.MethodOptions)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}/roles", h.JWTMiddleware(h.RequirePermission(model.PermUsersRead, h.GetUserRoles))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/users/{id:[0-9]+}/roles", h.JWTMiddleware(h.RequirePermission(model.PermUsersWrite, h.SetUserRoles))).Methods(http.MethodPut)
+
+	// Роли и разрешения
+	router.HandleFunc(apiV1+"/roles", h.JWTMiddleware(h.RequirePermission(model.PermRolesManage, h.GetRoles))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/roles", h.JWTMiddleware(h.RequirePermission(model.PermRolesManage, h.CreateRole))).Methods(http.MethodPost)
+	router.HandleFunc(apiV1+"/roles/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermRolesManage, h.GetRole))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/roles/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermRolesManage, h.ReplaceRole))).Methods(http.MethodPut)
+	router.HandleFunc(apiV1+"/roles/{id:[0-9]+}", h.JWTMiddleware(h.RequirePermission(model.PermRolesManage, h.DeleteRole))).Methods(http.MethodDelete)
+	router.HandleFunc(apiV1+"/permissions", h.JWTMiddleware(h.RequirePermission(model.PermRolesManage, h.GetPermissions))).Methods(http.MethodGet, http.MethodOptions)
+
+	// Журнал аудита и окончательное удаление
+	router.HandleFunc(apiV1+"/audit", h.JWTMiddleware(h.RequirePermission(model.PermAuditRead, h.GetAudit))).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV1+"/purge", h.JWTMiddleware(h.RequirePermission(model.PermDataPurge, h.Purge))).Methods(http.MethodPost, http.MethodOptions)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import "net/http"
+
+// Тело запроса на смену статуса сотрудника
+type statusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// Сменить статус сотрудника с указанием причины; версия записи обязательна в If-Match, в ответе — обновлённая запись.
+//
+//	POST /api/v1/employees/5/status
+//	{"status": "on_leave", "reason": "Отпуск по уходу за ребёнком"}
+func (h *Handlers) ChangeEmployeeStatusV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	var req statusRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.Employees.ChangeStatus(actorFromRequest(r), id, version, req.Status, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := newEmployeeResponse(updated, h.fieldView(r))
+	w.Header().Set("ETag", employeeETag(resp))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// История статусов сотрудника: кто, когда и почему менял статус
+//
+//	GET /api/v1/employees/5/status-history
+func (h *Handlers) GetEmployeeStatusHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := h.service.Employees.StatusHistory(actorFromRequest(r), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"items": history})
+}
@@ -2,10 +2,7 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
-	"go.mod/internal/model"
 	"net/http"
-	"strconv"
 )
 
 // Пользователи
@@ -13,29 +10,32 @@ import (
 // Получение одного пользователя по ID
 func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	idString := r.URL.Query()["id"]
-	if len(idString) == 0 {
-		http.Error(w, "Параметр 'id' отсутствует", http.StatusBadRequest)
+	id, ok := resourceID(w, r)
+	if !ok {
 		return
 	}
-	id, err := strconv.ParseInt(idString[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Некорректный параметр 'id'", http.StatusBadRequest)
+
+	includeDeleted, ok := queryIncludeDeleted(w, r)
+	if !ok {
 		return
 	}
 
-	user, err := h.db.GetUserByID(id)
+	user, err := h.service.Users.Get(actorFromRequest(r), id, includeDeleted)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка получения пользователя: %v", err), http.StatusNotFound)
+		writeError(w, err)
+		return
+	}
+	resp := newUserResponse(user)
+	if notModified(w, r, userETag(resp)) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(user)
+	err = json.NewEncoder(w).Encode(resp)
 	if err != nil {
 		return
 	}
@@ -44,18 +44,23 @@ func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 // Получение всех пользователей
 func (h *Handlers) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	includeDeleted, ok := queryIncludeDeleted(w, r)
+	if !ok {
 		return
 	}
 
-	users, err := h.db.GetAllUsers()
+	users, err := h.service.Users.List(actorFromRequest(r), includeDeleted)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка получения пользователей: %v", err), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(users)
+	err = json.NewEncoder(w).Encode(newUserResponses(users))
 	if err != nil {
 		return
 	}
@@ -64,24 +69,24 @@ func (h *Handlers) GetAllUsers(w http.ResponseWriter, r *http.Request) {
 // Создание нового пользователя
 func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	var user model.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		http.Error(w, "Неверный формат данных", http.StatusBadRequest)
+	var req userRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	if err := h.db.CreateUser(user); err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка создания пользователя: %v", err), http.StatusInternalServerError)
+	id, err := h.service.Users.Create(actorFromRequest(r), req.toModel())
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	err := json.NewEncoder(w).Encode(map[string]string{"message": "Пользователь успешно создан"})
+	err = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "message": tr(w, "user.created")})
 	if err != nil {
 		return
 	}
@@ -90,30 +95,54 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 // Удаление пользователя
 func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	idString := r.URL.Query()["id"]
-	if len(idString) == 0 {
-		http.Error(w, "Параметр 'id' отсутствует", http.StatusBadRequest)
+	id, ok := resourceID(w, r)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(idString[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Некорректный параметр 'id'", http.StatusBadRequest)
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
 		return
 	}
 
-	if err := h.db.DeleteUser(id); err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка удаления пользователя: %v", err), http.StatusInternalServerError)
+	if err := h.service.Users.Delete(actorFromRequest(r), id, version); err != nil {
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(map[string]string{"message": "Пользователь успешно удалён"})
+	err := json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "user.deleted")})
+	if err != nil {
+		return
+	}
+}
+
+// Восстановление удалённого пользователя
+//
+//	POST /restore_user?id=5
+func (h *Handlers) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := resourceID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Users.Restore(actorFromRequest(r), id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "user.restored")})
 	if err != nil {
 		return
 	}
@@ -122,37 +151,36 @@ func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
 // Обновление пользователя
 func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		httpError(w, "error.method_not_allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	idString := r.URL.Query()["id"]
-	if len(idString) == 0 {
-		http.Error(w, "Параметр 'id' отсутствует", http.StatusBadRequest)
+	id, ok := resourceID(w, r)
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseInt(idString[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Некорректный параметр 'id'", http.StatusBadRequest)
+	var req userRequest
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	var user model.User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		http.Error(w, "Неверный формат данных", http.StatusBadRequest)
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
 		return
 	}
 
-	if err := h.db.UpdateUser(id, user); err != nil {
-		http.Error(w, fmt.Sprintf("Ошибка обновления пользователя: %v", err), http.StatusInternalServerError)
+	updated, err := h.service.Users.Update(actorFromRequest(r), id, version, req.toModel())
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
 	// Ответ
+	w.Header().Set("ETag", userETag(newUserResponse(updated)))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(map[string]string{"message": "Пользователь успешно обновлён"})
+	err = json.NewEncoder(w).Encode(map[string]string{"message": tr(w, "user.updated")})
 	if err != nil {
 		return
 	}
@@ -0,0 +1,131 @@
+package messages
+
+import "go.mod/pkg/i18n"
+
+// Английский каталог
+var en = i18n.Catalog{
+	// Успешные операции
+	"employee.created":   {Other: "Employee created"},
+	"employee.updated":   {Other: "Employee updated"},
+	"employee.deleted":   {Other: "Employee deleted"},
+	"employee.restored":  {Other: "Employee restored"},
+	"user.created":       {Other: "User created"},
+	"user.updated":       {Other: "User updated"},
+	"user.deleted":       {Other: "User deleted"},
+	"user.restored":      {Other: "User restored"},
+	"department.created": {Other: "Department created"},
+	"department.updated": {Other: "Department updated"},
+	"department.deleted": {Other: "Department deleted"},
+
+	// Заголовки ответов об ошибках по статусу
+	"status.400": {Other: "Bad Request"},
+	"status.401": {Other: "Unauthorized"},
+	"status.403": {Other: "Forbidden"},
+	"status.404": {Other: "Not Found"},
+	"status.405": {Other: "Method Not Allowed"},
+	"status.409": {Other: "Conflict"},
+	"status.412": {Other: "Precondition Failed"},
+	"status.413": {Other: "Request Entity Too Large"},
+	"status.415": {Other: "Unsupported Media Type"},
+	"status.428": {Other: "Precondition Required"},
+	"status.429": {Other: "Too Many Requests"},
+	"status.500": {Other: "Internal Server Error"},
+	"status.503": {Other: "Service Unavailable"},
+
+	// Ошибки
+	"error.validation":          {Other: "Invalid data"},
+	"error.not_found":           {Other: "Record not found"},
+	"error.duplicate":           {Other: "Record already exists"},
+	"error.referenced":          {Other: "The record is referenced by other records or refers to a missing one"},
+	"error.conflict":            {Other: "Data conflict"},
+	"error.precondition_failed": {Other: "The record has changed since it was retrieved"},
+	"error.patch_test_failed":   {Other: "A test operation in the patch failed"},
+	"error.forbidden":           {Other: "Access denied"},
+	"error.invalid_credentials": {Other: "Invalid username or password"},
+	"error.invalid_token":       {Other: "Invalid token"},
+	"error.login_rate_limited":  {One: "Too many login attempts, try again in {count} second", Other: "Too many login attempts, try again in {count} seconds"},
+	"error.login_delayed":       {One: "The previous login attempt failed, try again in {count} second", Other: "The previous login attempt failed, try again in {count} seconds"},
+	"error.account_locked":      {One: "The account is locked after repeated failed login attempts, try again in {count} second or contact an administrator", Other: "The account is locked after repeated failed login attempts, try again in {count} seconds or contact an administrator"},
+	"error.token_missing":       {Other: "Missing authorization token"},
+	"error.permission_denied":   {Other: "Insufficient permissions: {permission} is required"},
+	"error.unavailable":         {Other: "Service temporarily unavailable, please retry later"},
+	"error.internal":            {Other: "Internal server error"},
+	"error.method_not_allowed":  {Other: "Method not allowed"},
+	"error.invalid_body":        {Other: "Invalid request body"},
+	"error.param_missing":       {Other: "Missing parameter '{name}'"},
+	"error.param_invalid":       {Other: "Invalid parameter '{name}'"},
+	"error.orgchart_format":     {Other: "Parameter 'format' must be json, dot or svg"},
+	"error.if_match_required":   {Other: "An If-Match header with the record ETag is required"},
+	"error.if_match_mismatch":   {Other: "If-Match does not match the current version of the record"},
+	"error.patch_media_type":    {Other: "Unsupported patch format"},
+	"error.route_not_found":     {Other: "Resource {path} not found"},
+
+	// Доступ
+	"forbidden.employees_view_deleted": {Other: "insufficient permissions to view deleted employees"},
+	"forbidden.employees_create":       {Other: "insufficient permissions to create employees"},
+	"forbidden.employees_update":       {Other: "insufficient permissions to modify employees"},
+	"forbidden.employees_delete":       {Other: "insufficient permissions to delete employees"},
+	"forbidden.employees_restore":      {Other: "insufficient permissions to restore employees"},
+	"forbidden.users_view":             {Other: "insufficient permissions to view users"},
+	"forbidden.users_create":           {Other: "insufficient permissions to create users"},
+	"forbidden.users_update":           {Other: "insufficient permissions to modify users"},
+	"forbidden.users_delete":           {Other: "insufficient permissions to delete users"},
+	"forbidden.users_delete_self":      {Other: "you cannot delete your own account"},
+	"forbidden.users_restore":          {Other: "insufficient permissions to restore users"},
+	"forbidden.users_unlock":           {Other: "insufficient permissions to unlock users"},
+	"forbidden.departments_create":     {Other: "insufficient permissions to create departments"},
+	"forbidden.departments_update":     {Other: "insufficient permissions to modify departments"},
+	"forbidden.departments_delete":     {Other: "insufficient permissions to delete departments"},
+	"forbidden.audit_view":             {Other: "insufficient permissions to view the audit log"},
+	"forbidden.purge":                  {Other: "insufficient permissions to purge deleted records"},
+	"forbidden.history_view":           {Other: "insufficient permissions to view employee change history"},
+	"forbidden.status_history_view":    {Other: "insufficient permissions to view the status history"},
+	"forbidden.status_hidden":          {Other: "the employee status is hidden from your roles"},
+	"forbidden.job_history_view":       {Other: "insufficient permissions to view the job history"},
+	"forbidden.roles_manage":           {Other: "insufficient permissions to manage roles"},
+	"forbidden.role_admin":             {Other: "the administrator role cannot be modified"},
+	"forbidden.role_permission_grant":  {Other: "you cannot add permission {permission} to a role: you do not have it"},
+	"forbidden.users_roles_self":       {Other: "you cannot change your own roles"},
+	"forbidden.users_role_grant":       {Other: "you cannot grant role {role}: you do not have all of its permissions"},
+	"forbidden.users_admin":            {Other: "only an administrator can change an administrator's password or roles"},
+
+	// Проверка полей
+	"validation.required":                {Other: "required field"},
+	"validation.too_long":                {One: "at most {count} character", Other: "at most {count} characters"},
+	"validation.too_short":               {One: "at least {count} character", Other: "at least {count} characters"},
+	"validation.email":                   {Other: "invalid email address"},
+	"validation.phone":                   {Other: "phone number in international E.164 format, e.g. +992901234567"},
+	"validation.unknown_field":           {Other: "unknown field"},
+	"validation.type":                    {Other: "invalid value type, expected {type}"},
+	"validation.date":                    {Other: "date must be in YYYY-MM-DD format"},
+	"validation.before_hire_date":        {Other: "must not be earlier than the hire date"},
+	"validation.status_transition":       {Other: "status {from} cannot be changed to {to}, allowed: {values}"},
+	"validation.job_action_status":       {Other: "action {action} is not allowed for an employee with status {status}"},
+	"validation.role_not_found":          {Other: "role {role} not found"},
+	"validation.role_name":               {Other: "lowercase latin letters, digits and _, starting with a letter"},
+	"validation.permission_unknown":      {Other: "unknown permission {permission}; allowed: {values}"},
+	"validation.one_of":                  {Other: "invalid value, allowed: {values}"},
+	"validation.range":                   {Other: "must be between {min} and {max}"},
+	"validation.negative":                {Other: "must not be negative"},
+	"validation.cursor":                  {Other: "invalid cursor"},
+	"validation.search_query":            {One: "at least {count} character with at least one letter or digit", Other: "at least {count} characters with at least one letter or digit"},
+	"validation.manager_cycle":           {Other: "an employee cannot report to their own subordinate"},
+	"validation.manager_self":            {Other: "an employee cannot be their own manager"},
+	"validation.employee_not_found":      {Other: "employee not found"},
+	"validation.department_not_found":    {Other: "department not found"},
+	"validation.department_out_of_scope": {Other: "department is outside your access scope: only your department or its subdepartments are allowed"},
+	"validation.department_id":           {Other: "invalid department ID"},
+	"validation.department_cycle":        {Other: "a department cannot be placed inside its own subdepartment"},
+	"validation.department_self_parent":  {Other: "a department cannot be its own parent"},
+	"validation.subdepartments":          {Other: "can only be used together with department_id"},
+	"validation.cursor_with_offset":      {Other: "cannot be combined with offset"},
+	"validation.sort_unavailable":        {Other: "sorting by {field} is not supported"},
+	"validation.field_hidden":            {Other: "field {field} is hidden from your roles"},
+	"validation.sort_duplicate":          {Other: "field {field} is specified more than once"},
+	"validation.to_before_from":          {Other: "must be later than from"},
+	"validation.patch_format":            {Other: "unknown patch format {format}"},
+	"validation.patch":                   {Other: "invalid patch: {error}"},
+	"validation.read_only":               {Other: "read-only field"},
+	"validation.version_read_only":       {Other: "read-only field, pass the version in If-Match"},
+	"validation.deleted_read_only":       {Other: "read-only field, use delete and restore instead"},
+}
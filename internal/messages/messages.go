@@ -0,0 +1,32 @@
+// Package messages — каталог сообщений API на русском, английском и таджикском языках
+package messages
+
+import "go.mod/pkg/i18n"
+
+// Поддерживаемые языки
+const (
+	RU = "ru" // язык по умолчанию
+	EN = "en"
+	TG = "tg"
+)
+
+// Bundle — каталоги сообщений всех языков
+var Bundle = newBundle()
+
+func newBundle() *i18n.Bundle {
+	b := i18n.NewBundle(RU)
+	b.Add(RU, ru)
+	b.Add(EN, en)
+	b.Add(TG, tg)
+	return b
+}
+
+// Translate — текст сообщения на языке lang
+func Translate(lang string, text i18n.Text) string {
+	return Bundle.Translate(lang, text)
+}
+
+// Default — текст сообщения на языке по умолчанию (для текстов ошибок и логов)
+func Default(text i18n.Text) string {
+	return Bundle.Translate(RU, text)
+}
@@ -0,0 +1,136 @@
+package messages
+
+import "go.mod/pkg/i18n"
+
+// Русский каталог — основной: в нём есть все ключи
+var ru = i18n.Catalog{
+	// Успешные операции
+	"employee.created":   {Other: "Сотрудник успешно создан"},
+	"employee.updated":   {Other: "Сотрудник успешно обновлён"},
+	"employee.deleted":   {Other: "Сотрудник успешно удалён"},
+	"employee.restored":  {Other: "Сотрудник успешно восстановлен"},
+	"user.created":       {Other: "Пользователь успешно создан"},
+	"user.updated":       {Other: "Пользователь успешно обновлён"},
+	"user.deleted":       {Other: "Пользователь успешно удалён"},
+	"user.restored":      {Other: "Пользователь успешно восстановлен"},
+	"department.created": {Other: "Отдел успешно создан"},
+	"department.updated": {Other: "Отдел успешно обновлён"},
+	"department.deleted": {Other: "Отдел успешно удалён"},
+
+	// Заголовки ответов об ошибках по статусу
+	"status.400": {Other: "Некорректный запрос"},
+	"status.401": {Other: "Требуется авторизация"},
+	"status.403": {Other: "Доступ запрещён"},
+	"status.404": {Other: "Не найдено"},
+	"status.405": {Other: "Метод не разрешён"},
+	"status.409": {Other: "Конфликт"},
+	"status.412": {Other: "Условие не выполнено"},
+	"status.413": {Other: "Слишком большой запрос"},
+	"status.415": {Other: "Неподдерживаемый тип данных"},
+	"status.428": {Other: "Требуется условие"},
+	"status.429": {Other: "Слишком много запросов"},
+	"status.500": {Other: "Внутренняя ошибка сервера"},
+	"status.503": {Other: "Сервис недоступен"},
+
+	// Ошибки
+	"error.validation":          {Other: "Некорректные данные"},
+	"error.not_found":           {Other: "Запись не найдена"},
+	"error.duplicate":           {Other: "Запись уже существует"},
+	"error.referenced":          {Other: "На запись ссылаются другие записи или она ссылается на несуществующую"},
+	"error.conflict":            {Other: "Конфликт данных"},
+	"error.precondition_failed": {Other: "Запись изменилась с момента получения"},
+	"error.patch_test_failed":   {Other: "Проверка test в патче не прошла"},
+	"error.forbidden":           {Other: "Доступ запрещён"},
+	"error.invalid_credentials": {Other: "Неверный логин или пароль"},
+	"error.invalid_token":       {Other: "Недействительный токен"},
+	"error.login_rate_limited":  {One: "Слишком много попыток входа, повторите через {count} секунду", Few: "Слишком много попыток входа, повторите через {count} секунды", Many: "Слишком много попыток входа, повторите через {count} секунд", Other: "Слишком много попыток входа, повторите через {count} секунды"},
+	"error.login_delayed":       {One: "Предыдущая попытка входа не удалась, повторите через {count} секунду", Few: "Предыдущая попытка входа не удалась, повторите через {count} секунды", Many: "Предыдущая попытка входа не удалась, повторите через {count} секунд", Other: "Предыдущая попытка входа не удалась, повторите через {count} секунды"},
+	"error.account_locked":      {One: "Учётная запись заблокирована после нескольких неудачных попыток входа, повторите через {count} секунду или обратитесь к администратору", Few: "Учётная запись заблокирована после нескольких неудачных попыток входа, повторите через {count} секунды или обратитесь к администратору", Many: "Учётная запись заблокирована после нескольких неудачных попыток входа, повторите через {count} секунд или обратитесь к администратору", Other: "Учётная запись заблокирована после нескольких неудачных попыток входа, повторите через {count} секунды или обратитесь к администратору"},
+	"error.token_missing":       {Other: "Отсутствует токен авторизации"},
+	"error.permission_denied":   {Other: "Недостаточно прав: требуется разрешение {permission}"},
+	"error.unavailable":         {Other: "Сервис временно недоступен, повторите запрос позже"},
+	"error.internal":            {Other: "Внутренняя ошибка сервера"},
+	"error.method_not_allowed":  {Other: "Метод не разрешён"},
+	"error.invalid_body":        {Other: "Неверный формат данных"},
+	"error.param_missing":       {Other: "Параметр '{name}' отсутствует"},
+	"error.param_invalid":       {Other: "Некорректный параметр '{name}'"},
+	"error.orgchart_format":     {Other: "Параметр 'format' может быть json, dot или svg"},
+	"error.if_match_required":   {Other: "Требуется заголовок If-Match с ETag записи"},
+	"error.if_match_mismatch":   {Other: "Заголовок If-Match не совпадает с текущей версией записи"},
+	"error.patch_media_type":    {Other: "Неподдерживаемый формат патча"},
+	"error.route_not_found":     {Other: "Ресурс {path} не найден"},
+
+	// Доступ
+	"forbidden.employees_view_deleted": {Other: "недостаточно прав для просмотра удалённых сотрудников"},
+	"forbidden.employees_create":       {Other: "недостаточно прав для создания сотрудников"},
+	"forbidden.employees_update":       {Other: "недостаточно прав для изменения сотрудников"},
+	"forbidden.employees_delete":       {Other: "недостаточно прав для удаления сотрудников"},
+	"forbidden.employees_restore":      {Other: "недостаточно прав для восстановления сотрудников"},
+	"forbidden.users_view":             {Other: "недостаточно прав для просмотра пользователей"},
+	"forbidden.users_create":           {Other: "недостаточно прав для создания пользователей"},
+	"forbidden.users_update":           {Other: "недостаточно прав для изменения пользователей"},
+	"forbidden.users_delete":           {Other: "недостаточно прав для удаления пользователей"},
+	"forbidden.users_delete_self":      {Other: "нельзя удалить собственную учётную запись"},
+	"forbidden.users_restore":          {Other: "недостаточно прав для восстановления пользователей"},
+	"forbidden.users_unlock":           {Other: "недостаточно прав для разблокировки пользователей"},
+	"forbidden.departments_create":     {Other: "недостаточно прав для создания отделов"},
+	"forbidden.departments_update":     {Other: "недостаточно прав для изменения отделов"},
+	"forbidden.departments_delete":     {Other: "недостаточно прав для удаления отделов"},
+	"forbidden.audit_view":             {Other: "недостаточно прав для просмотра журнала аудита"},
+	"forbidden.purge":                  {Other: "недостаточно прав для очистки удалённых записей"},
+	"forbidden.history_view":           {Other: "недостаточно прав для просмотра истории изменений сотрудников"},
+	"forbidden.status_history_view":    {Other: "недостаточно прав для просмотра истории статусов"},
+	"forbidden.status_hidden":          {Other: "статус сотрудника скрыт для ваших ролей"},
+	"forbidden.job_history_view":       {Other: "недостаточно прав для просмотра кадровой истории"},
+	"forbidden.roles_manage":           {Other: "недостаточно прав для управления ролями"},
+	"forbidden.role_admin":             {Other: "роль администратора изменить нельзя"},
+	"forbidden.role_permission_grant":  {Other: "нельзя добавить роли разрешение {permission}: у вас его нет"},
+	"forbidden.users_roles_self":       {Other: "нельзя изменить собственные роли"},
+	"forbidden.users_role_grant":       {Other: "нельзя выдать роль {role}: у вас нет всех её разрешений"},
+	"forbidden.users_admin":            {Other: "пароль и роли администратора может менять только администратор"},
+
+	// Проверка полей
+	"validation.required":           {Other: "обязательное поле"},
+	"validation.too_long":           {One: "не более {count} символа", Few: "не более {count} символов", Many: "не более {count} символов", Other: "не более {count} символа"},
+	"validation.too_short":          {One: "не менее {count} символа", Few: "не менее {count} символов", Many: "не менее {count} символов", Other: "не менее {count} символа"},
+	"validation.email":              {Other: "некорректный адрес электронной почты"},
+	"validation.phone":              {Other: "номер телефона в международном формате E.164, например +992901234567"},
+	"validation.unknown_field":      {Other: "неизвестное поле"},
+	"validation.type":               {Other: "неверный тип значения, ожидается {type}"},
+	"validation.date":               {Other: "дата должна быть в формате ГГГГ-ММ-ДД"},
+	"validation.before_hire_date":   {Other: "не может быть раньше даты приёма на работу"},
+	"validation.status_transition":  {Other: "статус {from} нельзя сменить на {to}, допустимы: {values}"},
+	"validation.job_action_status":  {Other: "действие {action} недопустимо для сотрудника со статусом {status}"},
+	"validation.role_not_found":     {Other: "роль {role} не найдена"},
+	"validation.role_name":          {Other: "латинские строчные буквы, цифры и _, начиная с буквы"},
+	"validation.permission_unknown": {Other: "неизвестное разрешение {permission}; допустимые: {values}"},
+	"validation.one_of":             {Other: "недопустимое значение, допустимы: {values}"},
+	"validation.range":              {Other: "допустимо от {min} до {max}"},
+	"validation.negative":           {Other: "не может быть отрицательным"},
+	"validation.cursor":             {Other: "некорректный курсор"},
+	"validation.search_query": {
+		One:   "не менее {count} символа, должна быть хотя бы одна буква или цифра",
+		Few:   "не менее {count} символов, должна быть хотя бы одна буква или цифра",
+		Many:  "не менее {count} символов, должна быть хотя бы одна буква или цифра",
+		Other: "не менее {count} символа, должна быть хотя бы одна буква или цифра",
+	},
+	"validation.manager_cycle":           {Other: "сотрудник не может подчиняться своему подчинённому"},
+	"validation.manager_self":            {Other: "сотрудник не может быть руководителем сам себе"},
+	"validation.employee_not_found":      {Other: "сотрудник не найден"},
+	"validation.department_not_found":    {Other: "отдел не найден"},
+	"validation.department_out_of_scope": {Other: "отдел вне вашей области доступа: можно выбрать только свой отдел или его подотделы"},
+	"validation.department_id":           {Other: "некорректный ID отдела"},
+	"validation.department_cycle":        {Other: "отдел не может входить в собственный подотдел"},
+	"validation.department_self_parent":  {Other: "отдел не может быть вышестоящим сам для себя"},
+	"validation.subdepartments":          {Other: "используется только вместе с department_id"},
+	"validation.cursor_with_offset":      {Other: "нельзя использовать вместе с offset"},
+	"validation.sort_unavailable":        {Other: "сортировка по полю {field} недоступна"},
+	"validation.field_hidden":            {Other: "поле {field} скрыто для ваших ролей"},
+	"validation.sort_duplicate":          {Other: "поле {field} указано несколько раз"},
+	"validation.to_before_from":          {Other: "должно быть позже from"},
+	"validation.patch_format":            {Other: "неизвестный формат патча {format}"},
+	"validation.patch":                   {Other: "{error}"},
+	"validation.read_only":               {Other: "поле только для чтения"},
+	"validation.version_read_only":       {Other: "поле только для чтения, версия передаётся в If-Match"},
+	"validation.deleted_read_only":       {Other: "поле только для чтения, используйте удаление и восстановление"},
+}
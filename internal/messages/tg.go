@@ -0,0 +1,132 @@
+package messages
+
+import "go.mod/pkg/i18n"
+
+// Таджикский каталог. После числительных существительное в таджикском не изменяется,
+// поэтому у сообщений с количеством формы совпадают.
+var tg = i18n.Catalog{
+	// Успешные операции
+	"employee.created":   {Other: "Корманд бомуваффақият илова шуд"},
+	"employee.updated":   {Other: "Маълумоти корманд бомуваффақият навсозӣ шуд"},
+	"employee.deleted":   {Other: "Корманд бомуваффақият нест карда шуд"},
+	"employee.restored":  {Other: "Корманд бомуваффақият барқарор карда шуд"},
+	"user.created":       {Other: "Корбар бомуваффақият эҷод шуд"},
+	"user.updated":       {Other: "Корбар бомуваффақият навсозӣ шуд"},
+	"user.deleted":       {Other: "Корбар бомуваффақият нест карда шуд"},
+	"user.restored":      {Other: "Корбар бомуваффақият барқарор карда шуд"},
+	"department.created": {Other: "Шуъба бомуваффақият эҷод шуд"},
+	"department.updated": {Other: "Шуъба бомуваффақият навсозӣ шуд"},
+	"department.deleted": {Other: "Шуъба бомуваффақият нест карда шуд"},
+
+	// Заголовки ответов об ошибках по статусу
+	"status.400": {Other: "Дархости нодуруст"},
+	"status.401": {Other: "Авторизатсия лозим аст"},
+	"status.403": {Other: "Дастрасӣ манъ аст"},
+	"status.404": {Other: "Ёфт нашуд"},
+	"status.405": {Other: "Усул иҷозат дода нашудааст"},
+	"status.409": {Other: "Зиддият"},
+	"status.412": {Other: "Шарт иҷро нашуд"},
+	"status.413": {Other: "Дархост хеле калон аст"},
+	"status.415": {Other: "Навъи маълумот дастгирӣ намешавад"},
+	"status.428": {Other: "Шарт лозим аст"},
+	"status.429": {Other: "Дархостҳо аз ҳад зиёданд"},
+	"status.500": {Other: "Хатои дохилии сервер"},
+	"status.503": {Other: "Хидмат дастнорас аст"},
+
+	// Ошибки
+	"error.validation":          {Other: "Маълумоти нодуруст"},
+	"error.not_found":           {Other: "Сабт ёфт нашуд"},
+	"error.duplicate":           {Other: "Сабт аллакай вуҷуд дорад"},
+	"error.referenced":          {Other: "Ба сабт сабтҳои дигар истинод мекунанд ё он ба сабти вуҷуднадошта истинод мекунад"},
+	"error.conflict":            {Other: "Зиддияти маълумот"},
+	"error.precondition_failed": {Other: "Сабт пас аз гирифтан тағйир ёфтааст"},
+	"error.patch_test_failed":   {Other: "Санҷиши test дар патч нагузашт"},
+	"error.forbidden":           {Other: "Дастрасӣ манъ аст"},
+	"error.invalid_credentials": {Other: "Логин ё парол нодуруст аст"},
+	"error.invalid_token":       {Other: "Токени нодуруст"},
+	"error.login_rate_limited":  {Other: "Кӯшишҳои воридшавӣ аз ҳад зиёданд, пас аз {count} сония такрор кунед"},
+	"error.login_delayed":       {Other: "Кӯшиши қаблии воридшавӣ ноком шуд, пас аз {count} сония такрор кунед"},
+	"error.account_locked":      {Other: "Ҳисоб пас аз якчанд кӯшиши ноками воридшавӣ баста шуд, пас аз {count} сония такрор кунед ё ба маъмур муроҷиат намоед"},
+	"error.token_missing":       {Other: "Токени авторизатсия мавҷуд нест"},
+	"error.permission_denied":   {Other: "Ҳуқуқ нокифоя аст: иҷозати {permission} лозим аст"},
+	"error.unavailable":         {Other: "Хидмат муваққатан дастнорас аст, дертар такрор кунед"},
+	"error.internal":            {Other: "Хатои дохилии сервер"},
+	"error.method_not_allowed":  {Other: "Усул иҷозат дода нашудааст"},
+	"error.invalid_body":        {Other: "Формати нодурусти маълумот"},
+	"error.param_missing":       {Other: "Параметри '{name}' мавҷуд нест"},
+	"error.param_invalid":       {Other: "Параметри нодурусти '{name}'"},
+	"error.orgchart_format":     {Other: "Параметри 'format' метавонад json, dot ё svg бошад"},
+	"error.if_match_required":   {Other: "Сарлавҳаи If-Match бо ETag-и сабт лозим аст"},
+	"error.if_match_mismatch":   {Other: "Сарлавҳаи If-Match ба версияи ҷории сабт мувофиқат намекунад"},
+	"error.patch_media_type":    {Other: "Формати патч дастгирӣ намешавад"},
+	"error.route_not_found":     {Other: "Манбаи {path} ёфт нашуд"},
+
+	// Доступ
+	"forbidden.employees_view_deleted": {Other: "барои дидани кормандони нестшуда ҳуқуқ нокифоя аст"},
+	"forbidden.employees_create":       {Other: "барои илова кардани кормандон ҳуқуқ нокифоя аст"},
+	"forbidden.employees_update":       {Other: "барои тағйир додани кормандон ҳуқуқ нокифоя аст"},
+	"forbidden.employees_delete":       {Other: "барои нест кардани кормандон ҳуқуқ нокифоя аст"},
+	"forbidden.employees_restore":      {Other: "барои барқарор кардани кормандон ҳуқуқ нокифоя аст"},
+	"forbidden.users_view":             {Other: "барои дидани корбарон ҳуқуқ нокифоя аст"},
+	"forbidden.users_create":           {Other: "барои эҷод кардани корбарон ҳуқуқ нокифоя аст"},
+	"forbidden.users_update":           {Other: "барои тағйир додани корбарон ҳуқуқ нокифоя аст"},
+	"forbidden.users_delete":           {Other: "барои нест кардани корбарон ҳуқуқ нокифоя аст"},
+	"forbidden.users_delete_self":      {Other: "ҳисоби худро нест кардан мумкин нест"},
+	"forbidden.users_restore":          {Other: "барои барқарор кардани корбарон ҳуқуқ нокифоя аст"},
+	"forbidden.users_unlock":           {Other: "барои кушодани қулфи корбарон ҳуқуқ нокифоя аст"},
+	"forbidden.departments_create":     {Other: "барои эҷод кардани шуъбаҳо ҳуқуқ нокифоя аст"},
+	"forbidden.departments_update":     {Other: "барои тағйир додани шуъбаҳо ҳуқуқ нокифоя аст"},
+	"forbidden.departments_delete":     {Other: "барои нест кардани шуъбаҳо ҳуқуқ нокифоя аст"},
+	"forbidden.audit_view":             {Other: "барои дидани маҷаллаи аудит ҳуқуқ нокифоя аст"},
+	"forbidden.purge":                  {Other: "барои тоза кардани сабтҳои нестшуда ҳуқуқ нокифоя аст"},
+	"forbidden.history_view":           {Other: "барои дидани таърихи тағйироти кормандон ҳуқуқ нокифоя аст"},
+	"forbidden.status_history_view":    {Other: "барои дидани таърихи вазъҳо ҳуқуқ нокифоя аст"},
+	"forbidden.status_hidden":          {Other: "вазъи корманд барои нақшҳои шумо пинҳон аст"},
+	"forbidden.job_history_view":       {Other: "барои дидани таърихи кадрӣ ҳуқуқ нокифоя аст"},
+	"forbidden.roles_manage":           {Other: "барои идоракунии нақшҳо ҳуқуқ нокифоя аст"},
+	"forbidden.role_admin":             {Other: "нақши администраторро тағйир додан мумкин нест"},
+	"forbidden.role_permission_grant":  {Other: "ба нақш иҷозати {permission}-ро илова кардан мумкин нест: шумо онро надоред"},
+	"forbidden.users_roles_self":       {Other: "нақшҳои худро тағйир додан мумкин нест"},
+	"forbidden.users_role_grant":       {Other: "нақши {role}-ро додан мумкин нест: шумо ҳамаи иҷозатҳои онро надоред"},
+	"forbidden.users_admin":            {Other: "пароль ва нақшҳои маъмурро танҳо маъмур тағйир дода метавонад"},
+
+	// Проверка полей
+	"validation.required":                {Other: "майдони ҳатмӣ"},
+	"validation.too_long":                {Other: "на зиёда аз {count} аломат"},
+	"validation.too_short":               {Other: "на камтар аз {count} аломат"},
+	"validation.email":                   {Other: "суроғаи почтаи электронӣ нодуруст аст"},
+	"validation.phone":                   {Other: "рақами телефон дар формати байналмилалии E.164, масалан +992901234567"},
+	"validation.unknown_field":           {Other: "майдони номаълум"},
+	"validation.type":                    {Other: "навъи қимат нодуруст аст, {type} интизор меравад"},
+	"validation.date":                    {Other: "сана бояд дар формати СССС-ММ-РР бошад"},
+	"validation.before_hire_date":        {Other: "наметавонад аз санаи ба кор қабул шудан пештар бошад"},
+	"validation.status_transition":       {Other: "вазъи {from}-ро ба {to} иваз кардан мумкин нест, иҷозат дода мешавад: {values}"},
+	"validation.job_action_status":       {Other: "амали {action} барои корманди дорои вазъи {status} иҷозат дода намешавад"},
+	"validation.role_not_found":          {Other: "нақши {role} ёфт нашуд"},
+	"validation.role_name":               {Other: "ҳарфҳои хурди лотинӣ, рақамҳо ва _, аз ҳарф сар мешавад"},
+	"validation.permission_unknown":      {Other: "иҷозати номаълум {permission}; иҷозатдодашуда: {values}"},
+	"validation.one_of":                  {Other: "қимати номувофиқ, иҷозат дода мешавад: {values}"},
+	"validation.range":                   {Other: "аз {min} то {max} иҷозат дода мешавад"},
+	"validation.negative":                {Other: "манфӣ буда наметавонад"},
+	"validation.cursor":                  {Other: "курсори нодуруст"},
+	"validation.search_query":            {Other: "на камтар аз {count} аломат, бояд ақаллан як ҳарф ё рақам бошад"},
+	"validation.manager_cycle":           {Other: "корманд наметавонад ба зердасти худ тобеъ бошад"},
+	"validation.manager_self":            {Other: "корманд наметавонад роҳбари худаш бошад"},
+	"validation.employee_not_found":      {Other: "корманд ёфт нашуд"},
+	"validation.department_not_found":    {Other: "шуъба ёфт нашуд"},
+	"validation.department_out_of_scope": {Other: "шуъба берун аз доираи дастрасии шумост: танҳо шуъбаи худ ё зершуъбаҳои он мумкин аст"},
+	"validation.department_id":           {Other: "ID-и шуъба нодуруст аст"},
+	"validation.department_cycle":        {Other: "шуъба наметавонад ба зершуъбаи худ дохил шавад"},
+	"validation.department_self_parent":  {Other: "шуъба наметавонад шуъбаи болоии худаш бошад"},
+	"validation.subdepartments":          {Other: "танҳо якҷоя бо department_id истифода мешавад"},
+	"validation.cursor_with_offset":      {Other: "якҷоя бо offset истифода бурдан мумкин нест"},
+	"validation.sort_unavailable":        {Other: "мураттабсозӣ аз рӯи майдони {field} дастрас нест"},
+	"validation.field_hidden":            {Other: "майдони {field} барои нақшҳои шумо пинҳон аст"},
+	"validation.sort_duplicate":          {Other: "майдони {field} якчанд маротиба нишон дода шудааст"},
+	"validation.to_before_from":          {Other: "бояд баъд аз from бошад"},
+	"validation.patch_format":            {Other: "формати номаълуми патч {format}"},
+	"validation.patch":                   {Other: "патчи нодуруст: {error}"},
+	"validation.read_only":               {Other: "майдон танҳо барои хондан аст"},
+	"validation.version_read_only":       {Other: "майдон танҳо барои хондан аст, версия дар If-Match фиристода мешавад"},
+	"validation.deleted_read_only":       {Other: "майдон танҳо барои хондан аст, нест кардан ва барқарор карданро истифода баред"},
+}
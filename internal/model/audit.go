@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+// Действия в журнале аудита
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"  // Мягкое удаление
+	AuditRestore = "restore" // Восстановление после мягкого удаления
+	AuditPurge   = "purge"   // Окончательное удаление
+	AuditLock    = "lock"    // Блокировка учётной записи после неудачных попыток входа
+	AuditUnlock  = "unlock"  // Снятие блокировки администратором
+)
+
+// Сущности в журнале аудита
+const (
+	AuditEmployee   = "employee"
+	AuditUser       = "user"
+	AuditRole       = "role"
+	AuditDepartment = "department"
+)
+
+// Кто и в рамках какого HTTP-запроса изменяет данные
+type AuditMeta struct {
+	Actor     string // Логин пользователя
+	RequestID string // Значение заголовка X-Request-ID
+	Reason    string // Причина изменения (записывается в историю статусов сотрудника)
+}
+
+// Изменение одного поля; nil — значения не было (создание) или не стало (удаление)
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Запись журнала аудита
+type AuditEntry struct {
+	Id         int64                  `json:"id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Actor      string                 `json:"actor"`
+	Action     string                 `json:"action"`
+	Entity     string                 `json:"entity"`
+	EntityId   int64                  `json:"entity_id"`
+	RequestId  string                 `json:"request_id"`
+	Changes    map[string]FieldChange `json:"changes"`
+}
+
+// Параметры выборки журнала аудита; пустые поля не фильтруют
+type AuditQuery struct {
+	Entity    string
+	EntityID  int64
+	Actor     string
+	Action    string
+	RequestID string
+	From      time.Time // Не раньше (включительно)
+	To        time.Time // Раньше (не включительно)
+	Scope     Scope     // Записи о сотрудниках — только о тех, кто сейчас в этой области доступа
+	Limit     int
+	Offset    int
+}
+
+// Страница журнала аудита (новые записи первыми)
+type AuditPage struct {
+	Items  []AuditEntry `json:"items"`
+	Total  int          `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// Результат окончательного удаления записей, удалённых раньше Before
+type PurgeResult struct {
+	Before    time.Time `json:"deleted_before"`
+	Employees int       `json:"employees"`
+	Users     int       `json:"users"`
+}
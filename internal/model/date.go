@@ -0,0 +1,127 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidDate — дата не в формате ГГГГ-ММ-ДД
+var ErrInvalidDate = errors.New("дата должна быть в формате ГГГГ-ММ-ДД")
+
+// Date — календарная дата без времени и часового пояса. Нулевое значение — дата не указана:
+// в JSON это null, в базе NULL. Две даты можно сравнивать через ==.
+type Date struct {
+	t time.Time // Полночь UTC
+}
+
+// NewDate — дата по году, месяцу и дню
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf — дата момента t в его часовом поясе
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// Today — сегодняшняя дата по местному времени сервера
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// ParseDate — дата из строки ГГГГ-ММ-ДД; пустая строка — нулевая дата
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("%w: %q", ErrInvalidDate, s)
+	}
+	return Date{t: t}, nil
+}
+
+// IsZero — дата не указана
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Before — дата d раньше other
+func (d Date) Before(other Date) bool {
+	return d.t.Before(other.t)
+}
+
+// After — дата d позже other
+func (d Date) After(other Date) bool {
+	return d.t.After(other.t)
+}
+
+// String — ГГГГ-ММ-ДД или пустая строка для нулевой даты
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(time.DateOnly)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON — принимает "ГГГГ-ММ-ДД", а также null и "" (дата не указана)
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return ErrInvalidDate
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value — значение для колонки DATE (NULL для нулевой даты)
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.t, nil
+}
+
+// Scan — значение колонки DATE; часовой пояс драйвера отбрасывается
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	default:
+		return fmt.Errorf("нельзя прочитать дату из %T", src)
+	}
+	return nil
+}
+
+func (d *Date) scanString(s string) error {
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,15 @@
+package model
+
+// Отдел; отделы образуют дерево через ParentId
+type Department struct {
+	Id       int    `json:"id"`        // Уникальный идентификатор отдела
+	Name     string `json:"name"`      // Название (уникально без учёта регистра)
+	ParentId *int   `json:"parent_id"` // Вышестоящий отдел, nil — отдел верхнего уровня
+	HeadId   *int   `json:"head_id"`   // Руководитель отдела (ID сотрудника)
+}
+
+// Отдел вместе с подотделами (для вывода дерева)
+type DepartmentNode struct {
+	Department
+	Children []DepartmentNode `json:"children"`
+}
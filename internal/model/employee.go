@@ -1,16 +1,74 @@
 package model
 
+import (
+	"strings"
+	"time"
+)
+
+// Статусы сотрудника
+const (
+	StatusCandidate  = "candidate"
+	StatusOnboarding = "onboarding"
+	StatusActive     = "active"
+	StatusOnLeave    = "on_leave"
+	StatusSuspended  = "suspended"
+	StatusTerminated = "terminated"
+)
+
+// EmployeeStatuses — допустимые значения поля status
+var EmployeeStatuses = []string{StatusCandidate, StatusOnboarding, StatusActive, StatusOnLeave, StatusSuspended, StatusTerminated}
+
+// Employee — сотрудник. Ограничения полей в тегах validate соответствуют DDL таблицы employees
+// и проверяются в сервисе перед записью.
 type Employee struct {
-	Id          int    `json:"id"`          // Уникальный идентификатор сотрудника
-	LastName    string `json:"lastname"`    // Фамилия сотрудника
-	FirstName   string `json:"firstname"`   // Имя сотрудника
-	MiddleName  string `json:"middlename"`  // Отчество сотрудника
-	Position    string `json:"position"`    // Должность
-	Department  string `json:"department"`  // Название отдела
-	Email       string `json:"email"`       // Электронная почта
-	PhoneNumber string `json:"phonenumber"` // Номер телефона
-	HireDate    string `json:"hiredate"`    // Дата приёма на работу
-	Status      string `json:"status"`      // Дата приёма на работу
-	PhotoUrl    string `json:"photourl"`    // Ссылка на фотографию
-	Notes       string `json:"notes"`       // Дополнительные заметки
+	Id               int    `json:"id"`                                            // Уникальный идентификатор сотрудника
+	LastName         string `json:"lastname" validate:"required,max=100"`          // Фамилия сотрудника
+	FirstName        string `json:"firstname" validate:"required,max=100"`         // Имя сотрудника
+	MiddleName       string `json:"middlename" validate:"max=100"`                 // Отчество сотрудника
+	Position         string `json:"position" validate:"max=100"`                   // Должность
+	Department       string `json:"department" validate:"max=100"`                 // Название отдела (при записи используется, если не указан department_id)
+	DepartmentId     *int   `json:"department_id"`                                 // Отдел сотрудника
+	ManagerId        *int   `json:"manager_id"`                                    // Непосредственный руководитель (ID сотрудника)
+	Email            string `json:"email" validate:"max=150,email"`                // Электронная почта
+	PhoneNumber      string `json:"phonenumber" validate:"max=50,phone"`           // Номер телефона
+	HireDate         Date   `json:"hiredate"`                                      // Дата приёма на работу
+	ProbationEndDate Date   `json:"probation_end_date"`                            // Дата окончания испытательного срока
+	TerminationDate  Date   `json:"termination_date"`                              // Дата увольнения
+	Status           string `json:"status" validate:"max=50,enum=employee_status"` // Статус занятости (переходы — StatusTransitions)
+	PhotoUrl         string `json:"photourl"`                                      // Ссылка на фотографию
+	Notes            string `json:"notes"`                                         // Дополнительные заметки
+	Version          int    `json:"version"`                                       // Версия записи, увеличивается при каждом изменении (ETag)
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Время удаления, nil — запись не удалена
+	DeletedBy string     `json:"deleted_by,omitempty"` // Кто удалил запись
+}
+
+// FullName — фамилия, имя и отчество через пробел (пустые части пропускаются)
+func (e Employee) FullName() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{e.LastName, e.FirstName, e.MiddleName} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Поля сотрудника (json-имена), которые можно изменить частичным обновлением (PATCH).
+// Отдел задаётся через department_id; название отдела department только для чтения.
+var EmployeePatchFields = map[string]bool{
+	"lastname":           true,
+	"firstname":          true,
+	"middlename":         true,
+	"position":           true,
+	"department_id":      true,
+	"manager_id":         true,
+	"email":              true,
+	"phonenumber":        true,
+	"hiredate":           true,
+	"probation_end_date": true,
+	"termination_date":   true,
+	"status":             true,
+	"photourl":           true,
+	"notes":              true,
 }
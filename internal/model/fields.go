@@ -0,0 +1,186 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Видимость поля сотрудника для пользователя
+type FieldAccess int
+
+const (
+	FieldVisible FieldAccess = iota // Поле видно полностью
+	FieldMasked                     // Видна только часть значения (+992 ** *** **45, i***@example.com)
+	FieldHidden                     // Поле не показывается
+)
+
+// RestrictedEmployeeFields — поля сотрудника (json-имена), видимость которых настраивается по ролям.
+// ФИО, должность, отдел и руководитель видны всем, кому доступен сотрудник.
+var RestrictedEmployeeFields = []string{
+	"email", "phonenumber", "hiredate", "probation_end_date", "termination_date", "status", "photourl", "notes",
+}
+
+// MaskableEmployeeFields — поля, которые можно показывать маскированными
+var MaskableEmployeeFields = []string{"email", "phonenumber"}
+
+// FieldView — видимость полей сотрудника для конкретного пользователя.
+// Поля, которых нет в карте, видны полностью; nil — видно всё.
+type FieldView map[string]FieldAccess
+
+// Access — видимость поля field
+func (v FieldView) Access(field string) FieldAccess {
+	return v[field]
+}
+
+// Visible — поле field видно полностью
+func (v FieldView) Visible(field string) bool {
+	return v[field] == FieldVisible
+}
+
+// Hidden — поле field не показывается
+func (v FieldView) Hidden(field string) bool {
+	return v[field] == FieldHidden
+}
+
+// Restricted — часть полей пользователю не видна полностью
+func (v FieldView) Restricted() bool {
+	for _, access := range v {
+		if access != FieldVisible {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact — сотрудник в том виде, в каком его видит пользователь: скрытые поля обнулены, маскированные замаскированы
+func (v FieldView) Redact(e Employee) Employee {
+	for field, access := range v {
+		switch access {
+		case FieldHidden:
+			e = setEmployeeField(e, field, Employee{})
+		case FieldMasked:
+			switch field {
+			case "email":
+				e.Email = MaskEmail(e.Email)
+			case "phonenumber":
+				e.PhoneNumber = MaskPhone(e.PhoneNumber)
+			}
+		}
+	}
+	return e
+}
+
+// Keep — в записи e поля, которые пользователь не видит полностью, заменены значениями из current.
+// Так при записи пользователь не может ни стереть, ни подменить значение, которое ему не показано.
+func (v FieldView) Keep(e, current Employee) Employee {
+	for field, access := range v {
+		if access != FieldVisible {
+			e = setEmployeeField(e, field, current)
+		}
+	}
+	return e
+}
+
+// RedactChanges — изменения полей сотрудника из журнала аудита без скрытых полей и с маскированными значениями
+func (v FieldView) RedactChanges(changes map[string]FieldChange) map[string]FieldChange {
+	if !v.Restricted() {
+		return changes
+	}
+	redacted := make(map[string]FieldChange, len(changes))
+	for field, change := range changes {
+		switch v.Access(field) {
+		case FieldHidden:
+			continue
+		case FieldMasked:
+			change = FieldChange{Old: maskValue(field, change.Old), New: maskValue(field, change.New)}
+		}
+		redacted[field] = change
+	}
+	return redacted
+}
+
+// setEmployeeField — записывает в e значение поля field из src
+func setEmployeeField(e Employee, field string, src Employee) Employee {
+	switch field {
+	case "email":
+		e.Email = src.Email
+	case "phonenumber":
+		e.PhoneNumber = src.PhoneNumber
+	case "hiredate":
+		e.HireDate = src.HireDate
+	case "probation_end_date":
+		e.ProbationEndDate = src.ProbationEndDate
+	case "termination_date":
+		e.TerminationDate = src.TerminationDate
+	case "status":
+		e.Status = src.Status
+	case "photourl":
+		e.PhotoUrl = src.PhotoUrl
+	case "notes":
+		e.Notes = src.Notes
+	}
+	return e
+}
+
+// maskValue — маскированное значение поля field из журнала аудита (строка или nil)
+func maskValue(field string, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	switch field {
+	case "email":
+		return MaskEmail(s)
+	case "phonenumber":
+		return MaskPhone(s)
+	}
+	return value
+}
+
+// MaskPhone — номер телефона, в котором видны только код страны и две последние цифры:
+// +992931234545 -> +992 ** *** **45. Код страны — цифры перед девятью цифрами национального номера.
+func MaskPhone(phone string) string {
+	var digits []rune
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	national := digits
+	if strings.HasPrefix(phone, "+") {
+		b.WriteByte('+')
+		if len(digits) > 9 {
+			b.WriteString(string(digits[:len(digits)-9]))
+			b.WriteByte(' ')
+			national = digits[len(digits)-9:]
+		}
+	}
+
+	visible := 2
+	if len(national) <= visible {
+		visible = 0
+	}
+	masked := []rune(strings.Repeat("*", len(national)-visible) + string(national[len(national)-visible:]))
+	if len(masked) == 9 {
+		// Национальный номер группами 2-3-4, как его обычно записывают
+		b.WriteString(string(masked[:2]) + " " + string(masked[2:5]) + " " + string(masked[5:]))
+	} else {
+		b.WriteString(string(masked))
+	}
+	return b.String()
+}
+
+// MaskEmail — адрес, в котором от имени ящика видна только первая буква: ivanov@example.com -> i***@example.com
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return strings.Repeat("*", len([]rune(email)))
+	}
+	first := []rune(local)[0]
+	return string(first) + "***@" + domain
+}
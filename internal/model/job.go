@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Кадровые действия
+const (
+	JobHire        = "hire"        // Приём на работу
+	JobTransfer    = "transfer"    // Перевод в другой отдел или на другую должность
+	JobPromotion   = "promotion"   // Повышение
+	JobDemotion    = "demotion"    // Понижение
+	JobTermination = "termination" // Увольнение
+	JobRehire      = "rehire"      // Повторный приём
+)
+
+// JobActions — допустимые значения поля action
+var JobActions = []string{JobHire, JobTransfer, JobPromotion, JobDemotion, JobTermination, JobRehire}
+
+// JobAssignment — запись кадровой истории: должность и отдел сотрудника с даты EffectiveDate.
+// Запись с будущей датой вступает в силу автоматически: до этого AppliedAt == nil, и запись сотрудника не меняется.
+type JobAssignment struct {
+	Id            int64      `json:"id"`
+	EmployeeId    int64      `json:"employee_id"`
+	Action        string     `json:"action"`
+	EffectiveDate Date       `json:"effective_date"`
+	Position      string     `json:"position"`
+	DepartmentId  *int       `json:"department_id"`
+	Department    string     `json:"department"` // Название отдела (только для чтения)
+	Reason        string     `json:"reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CreatedBy     string     `json:"created_by"`
+	AppliedAt     *time.Time `json:"applied_at"` // Когда изменение перенесено в запись сотрудника
+}
+
+// Employed — статус означает, что сотрудник работает (кандидат ещё не принят, уволенный уже не работает).
+// Пустой статус у записей, созданных до появления статусов, считается работающим.
+func Employed(status string) bool {
+	return status != StatusCandidate && status != StatusTerminated
+}
@@ -0,0 +1,72 @@
+package model
+
+import "strings"
+
+// Параметры выборки списка сотрудников
+type EmployeeQuery struct {
+	// Фильтры (несколько значений одного фильтра объединяются через ИЛИ)
+	Department []string // Названия отделов
+	// Отдел по ID; вместе с Subdepartments — отдел и все его подотделы
+	DepartmentID   int64
+	Subdepartments bool
+	Position       []string
+	Status         []string
+	HiredFrom      string // Дата приёма не раньше (ГГГГ-ММ-ДД, включительно)
+	HiredTo        string // Дата приёма не позже (ГГГГ-ММ-ДД, включительно)
+	IncludeDeleted bool   // Вместе с мягко удалёнными сотрудниками
+	AsOf           Date   // Должность, отдел и занятость на дату (по кадровой истории); нулевая — текущие
+	Scope          Scope  // Область доступа пользователя (задаётся сервисом, не клиентом)
+
+	Sort   []SortField // Порядок сортировки; id всегда добавляется последним для однозначности
+	Limit  int
+	Offset int    // Постраничный вывод через смещение
+	Cursor string // Постраничный вывод по ключу (keyset); несовместим со смещением
+}
+
+// Поле сортировки
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Поля сотрудника, по которым разрешена сортировка
+var EmployeeSortFields = map[string]bool{
+	"id":         true,
+	"lastname":   true,
+	"firstname":  true,
+	"middlename": true,
+	"position":   true,
+	"department": true,
+	"email":      true,
+	"hiredate":   true,
+	"status":     true,
+}
+
+// SortKey — строковое представление порядка сортировки (lastname,-hiredate,id)
+func SortKey(sort []SortField) string {
+	parts := make([]string, len(sort))
+	for i, f := range sort {
+		if f.Desc {
+			parts[i] = "-" + f.Field
+		} else {
+			parts[i] = f.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Страница списка сотрудников
+type EmployeePage struct {
+	Items      []Employee `json:"items"`
+	Total      int        `json:"total"`                 // Всего записей с учётом фильтров
+	Limit      int        `json:"limit"`                 // Размер страницы
+	Offset     int        `json:"offset"`                // Смещение (0 при выборке по курсору)
+	NextCursor string     `json:"next_cursor,omitempty"` // Курсор следующей страницы, пусто — страница последняя
+}
+
+// Результат поиска сотрудника
+type EmployeeSearchResult struct {
+	Employee
+	Rank    float64 `json:"rank"`    // Релевантность: чем больше, тем выше в выдаче
+	Snippet string  `json:"snippet"` // Фрагмент с совпадениями, выделенными <mark>…</mark> (HTML экранирован)
+}
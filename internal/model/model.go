@@ -2,10 +2,12 @@ package model
 
 import "github.com/golang-jwt/jwt/v5"
 
-// Claims для JWT
+// Claims для JWT (уникальный идентификатор токена передаётся в RegisteredClaims.ID — claim "jti")
 type Claims struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Username     string   `json:"username"`
+	Roles        []string `json:"roles"`
+	DepartmentId *int     `json:"department_id,omitempty"` // Отдел пользователя (область доступа к сотрудникам)
+	Locale       string   `json:"locale,omitempty"`        // Язык из профиля пользователя
 	jwt.RegisteredClaims
 }
 
@@ -0,0 +1,10 @@
+package model
+
+// Узел оргструктуры: сотрудник и его подчинённые
+type OrgChartNode struct {
+	Id         int            `json:"id"`
+	Name       string         `json:"name"` // Фамилия и имя
+	Position   string         `json:"position"`
+	Department string         `json:"department"`
+	Reports    []OrgChartNode `json:"reports"` // Непосредственные подчинённые
+}
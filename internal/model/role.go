@@ -0,0 +1,74 @@
+package model
+
+// Разрешения: ресурс и действие. Проверяются в сервисах (Actor.Can) и на маршрутах (RequirePermission).
+const (
+	PermEmployeesRead           = "employees:read"            // Просмотр сотрудников, оргструктуры и поиска
+	PermEmployeesCreate         = "employees:create"          // Создание сотрудников
+	PermEmployeesUpdate         = "employees:update"          // Изменение сотрудников, их статуса и кадровые действия
+	PermEmployeesDelete         = "employees:delete"          // Удаление и восстановление сотрудников
+	PermEmployeesViewDeleted    = "employees:view_deleted"    // Просмотр удалённых сотрудников
+	PermEmployeesHistory        = "employees:history"         // История статусов и кадровая история
+	PermEmployeesAllDepartments = "employees:all_departments" // Сотрудники всех отделов; без него — только своего отдела (см. Scope)
+	PermDepartmentsRead         = "departments:read"          // Просмотр отделов
+	PermDepartmentsWrite        = "departments:write"         // Создание, изменение и удаление отделов
+	PermUsersRead               = "users:read"                // Просмотр пользователей
+	PermUsersWrite              = "users:write"               // Создание, изменение, удаление пользователей и назначение им ролей
+	PermAuditRead               = "audit:read"                // Журнал аудита
+	PermDataPurge               = "data:purge"                // Окончательное удаление записей
+	PermRolesManage             = "roles:manage"              // Управление ролями и их разрешениями
+)
+
+// Permissions — все известные разрешения
+var Permissions = []string{
+	PermEmployeesRead, PermEmployeesCreate, PermEmployeesUpdate, PermEmployeesDelete, PermEmployeesViewDeleted, PermEmployeesHistory,
+	PermEmployeesAllDepartments, PermDepartmentsRead, PermDepartmentsWrite,
+	PermUsersRead, PermUsersWrite,
+	PermAuditRead, PermDataPurge, PermRolesManage,
+}
+
+// Встроенные роли (создаются миграциями 0013_rbac и 0014_department_scope). У администратора всегда все разрешения,
+// его роль нельзя изменить; встроенные роли нельзя удалить.
+const (
+	RoleAdmin          = "admin"
+	RoleUser           = "user"
+	RoleHRManager      = "hr_manager"
+	RoleDepartmentHead = "department_head"
+	RoleViewer         = "viewer"
+	RoleAuditor        = "auditor"
+)
+
+// Role — роль: именованный набор разрешений. Пользователю можно назначить несколько ролей,
+// его разрешения — объединение разрешений всех ролей.
+type Role struct {
+	Id          int      `json:"id"`
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=200"`
+	Permissions []string `json:"permissions"`
+	Builtin     bool     `json:"builtin"` // Встроенная роль (только для чтения)
+}
+
+// BuiltinRoles — встроенные роли с разрешениями по умолчанию (те же, что в миграциях).
+// Руководитель отдела без employees:all_departments видит только сотрудников своего отдела.
+var BuiltinRoles = []Role{
+	{Name: RoleAdmin, Description: "Администратор: все разрешения", Permissions: Permissions, Builtin: true},
+	{Name: RoleUser, Description: "Пользователь: просмотр сотрудников и отделов", Builtin: true,
+		Permissions: []string{PermEmployeesRead, PermEmployeesAllDepartments, PermDepartmentsRead}},
+	{Name: RoleHRManager, Description: "Кадровик: ведение сотрудников и отделов", Builtin: true,
+		Permissions: []string{PermEmployeesRead, PermEmployeesCreate, PermEmployeesUpdate, PermEmployeesDelete,
+			PermEmployeesViewDeleted, PermEmployeesHistory, PermEmployeesAllDepartments, PermDepartmentsRead, PermDepartmentsWrite}},
+	{Name: RoleDepartmentHead, Description: "Руководитель отдела: просмотр и изменение сотрудников", Builtin: true,
+		Permissions: []string{PermEmployeesRead, PermEmployeesUpdate, PermEmployeesHistory, PermDepartmentsRead}},
+	{Name: RoleViewer, Description: "Наблюдатель: только просмотр", Builtin: true,
+		Permissions: []string{PermEmployeesRead, PermEmployeesAllDepartments, PermDepartmentsRead}},
+	{Name: RoleAuditor, Description: "Аудитор: просмотр, истории и журнал аудита", Builtin: true,
+		Permissions: []string{PermEmployeesRead, PermEmployeesViewDeleted, PermEmployeesHistory, PermEmployeesAllDepartments,
+			PermDepartmentsRead, PermUsersRead, PermAuditRead}},
+}
+
+// Scope — сотрудники, доступные пользователю. Нулевое значение — все; с Limited — только сотрудники отдела
+// DepartmentID и его подотделов (пользователю без отдела — никто). Ограничение входит в условия запросов
+// хранилища, поэтому сотрудник вне области не найден (404), а не запрещён.
+type Scope struct {
+	Limited      bool
+	DepartmentID int64
+}
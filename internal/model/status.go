@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Разрешённые переходы между статусами сотрудника. Из пустого статуса (не задан) можно перейти в любой;
+// уволенного можно принять повторно через оформление или сразу в работу.
+var StatusTransitions = map[string][]string{
+	StatusCandidate:  {StatusOnboarding, StatusActive, StatusTerminated},
+	StatusOnboarding: {StatusActive, StatusTerminated},
+	StatusActive:     {StatusOnLeave, StatusSuspended, StatusTerminated},
+	StatusOnLeave:    {StatusActive, StatusTerminated},
+	StatusSuspended:  {StatusActive, StatusTerminated},
+	StatusTerminated: {StatusOnboarding, StatusActive},
+}
+
+// CanTransition — можно ли сменить статус from на to (тот же статус — не смена)
+func CanTransition(from, to string) bool {
+	if from == "" || from == to {
+		return true
+	}
+	for _, next := range StatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange — смена статуса сотрудника в истории статусов
+type StatusChange struct {
+	Id         int64     `json:"id"`
+	EmployeeId int64     `json:"employee_id"`
+	From       string    `json:"from"` // Пустой — статус не был задан
+	To         string    `json:"to"`
+	Reason     string    `json:"reason"`
+	ChangedAt  time.Time `json:"changed_at"`
+	ChangedBy  string    `json:"changed_by"`
+	RequestId  string    `json:"request_id"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Refresh-токен (в базе хранится только SHA-256 от значения токена)
+type RefreshToken struct {
+	Id              int
+	UserId          int
+	FamilyId        string     // Все токены, полученные ротацией от одного входа, относятся к одному семейству
+	TokenHash       string     // SHA-256 от значения токена в hex
+	AccessJti       string     // jti access-токена, выданного вместе с этим refresh-токеном
+	AccessExpiresAt time.Time  // Срок действия этого access-токена
+	ExpiresAt       time.Time  // Срок действия refresh-токена
+	UsedAt          *time.Time // Когда токен был обменян на новую пару
+	RevokedAt       *time.Time // Когда семейство было отозвано
+}
+
+// Пара токенов, которую получает клиент
+type TokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // Время жизни access-токена в секундах
+}
+
+// Запрос на обновление токена или выход
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -1,9 +1,45 @@
 package model
 
-// Модель пользователя
+import "time"
+
+// Модель пользователя. Ограничения полей — в тегах validate (см. Employee).
+// Роли хранятся в таблице user_roles; разрешения пользователя — объединение разрешений его ролей.
 type User struct {
-	Id       int    `json:"id"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Id       int      `json:"id"`
+	Username string   `json:"username" validate:"required,max=100"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles"`
+	// Отдел пользователя: без разрешения employees:all_departments доступны только сотрудники этого отдела и его подотделов
+	DepartmentId *int   `json:"department_id"`
+	Locale       string `json:"locale" validate:"max=10,enum=locale"` // Язык сообщений API (ru, en, tg); пустой — по заголовку Accept-Language
+	Version      int    `json:"version"`                              // Версия записи, увеличивается при каждом изменении (ETag)
+
+	FailedAttempts int        `json:"failed_attempts"` // Неудачных попыток входа подряд (сбрасывается успешным входом)
+	LockedUntil    *time.Time `json:"locked_until"`    // До какого времени вход закрыт (задержка или блокировка), nil — открыт
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Время удаления, nil — запись не удалена
+	DeletedBy string     `json:"deleted_by,omitempty"` // Кто удалил запись
+}
+
+// LoginPolicy — защита от подбора пароля: после каждой неудачной попытки входа вход под этим логином
+// закрывается на время, которое удваивается с каждой следующей неудачей, а после MaxAttempts неудач подряд
+// учётная запись блокируется на LockoutDuration (снять блокировку раньше может администратор)
+type LoginPolicy struct {
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	Delay           time.Duration // Задержка после первой неудачной попытки
+	MaxDelay        time.Duration // Предел задержки
+}
+
+// LockedUntil — до какого времени закрыт вход после failures неудачных попыток подряд;
+// lockout — это блокировка учётной записи, а не задержка
+func (p LoginPolicy) LockedUntil(failures int, now time.Time) (until time.Time, lockout bool) {
+	if p.MaxAttempts > 0 && failures >= p.MaxAttempts {
+		return now.Add(p.LockoutDuration), true
+	}
+	delay := p.Delay
+	for i := 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return now.Add(min(delay, p.MaxDelay)), false
 }
@@ -0,0 +1,137 @@
+// Package orgchart — экспорт оргструктуры в Graphviz DOT и SVG
+package orgchart
+
+import (
+	"fmt"
+	"go.mod/internal/model"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// DOT — оргструктура на языке Graphviz (dot -Tpng orgchart.dot > orgchart.png)
+func DOT(roots []model.OrgChartNode) string {
+	var b strings.Builder
+	b.WriteString("digraph orgchart {\n")
+	b.WriteString("\trankdir=TB;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fillcolor=\"#f5f7fa\", fontname=\"Helvetica\"];\n")
+
+	var walk func(node model.OrgChartNode)
+	walk = func(node model.OrgChartNode) {
+		label := node.Name
+		if node.Position != "" {
+			label += "\n" + node.Position
+		}
+		fmt.Fprintf(&b, "\te%d [label=%s];\n", node.Id, dotQuote(label))
+		for _, report := range node.Reports {
+			fmt.Fprintf(&b, "\te%d -> e%d;\n", node.Id, report.Id)
+			walk(report)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote — строка в кавычках DOT; перевод строки становится \n
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// Размеры блоков SVG в пикселях
+const (
+	boxWidth  = 200
+	boxHeight = 56
+	gapX      = 24 // Между соседними блоками
+	gapY      = 48 // Между уровнями
+	margin    = 16
+	maxRunes  = 26 // Длинные строки обрезаются, чтобы поместиться в блок
+)
+
+// placed — блок с координатами левого верхнего угла
+type placed struct {
+	node model.OrgChartNode
+	x, y int
+}
+
+// SVG — оргструктура в виде дерева: руководитель над подчинёнными, блоки соединены линиями
+func SVG(roots []model.OrgChartNode) string {
+	var boxes []placed
+	var lines []string
+	slot, depth := 0, 0
+
+	// Листья занимают очередную позицию слева направо, руководитель — по центру над подчинёнными
+	var layout func(node model.OrgChartNode, level int) int
+	layout = func(node model.OrgChartNode, level int) int {
+		if level > depth {
+			depth = level
+		}
+		y := margin + level*(boxHeight+gapY)
+
+		var x int
+		if len(node.Reports) == 0 {
+			x = margin + slot*(boxWidth+gapX)
+			slot++
+		} else {
+			children := make([]int, len(node.Reports))
+			for i, report := range node.Reports {
+				children[i] = layout(report, level+1)
+			}
+			x = (children[0] + children[len(children)-1]) / 2
+
+			// Линии: вниз от руководителя, по горизонтали и вниз к подчинённому
+			midY := y + boxHeight + gapY/2
+			for _, cx := range children {
+				lines = append(lines, fmt.Sprintf(`<path d="M%d %d V%d H%d V%d"/>`,
+					x+boxWidth/2, y+boxHeight, midY, cx+boxWidth/2, y+boxHeight+gapY))
+			}
+		}
+
+		boxes = append(boxes, placed{node: node, x: x, y: y})
+		return x
+	}
+	for _, root := range roots {
+		layout(root, 0)
+	}
+
+	width, height := 2*margin, 2*margin
+	if slot > 0 {
+		width += slot*(boxWidth+gapX) - gapX
+		height += (depth+1)*(boxHeight+gapY) - gapY
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		width, height, width, height)
+	b.WriteString(`<g fill="none" stroke="#9aa5b1" stroke-width="1.5">` + "\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("</g>\n")
+
+	for _, box := range boxes {
+		fmt.Fprintf(&b, `<g id="e%d">`, box.node.Id)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="8" fill="#f5f7fa" stroke="#52606d"/>`,
+			box.x, box.y, boxWidth, boxHeight)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="14" font-weight="bold" fill="#1f2933">%s</text>`,
+			box.x+boxWidth/2, box.y+24, svgText(box.node.Name))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="12" fill="#52606d">%s</text>`,
+			box.x+boxWidth/2, box.y+42, svgText(box.node.Position))
+		b.WriteString("</g>\n")
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// svgText — обрезает длинную строку и экранирует её для XML
+func svgText(s string) string {
+	if utf8.RuneCountInString(s) > maxRunes {
+		s = string([]rune(s)[:maxRunes-1]) + "…"
+	}
+	return html.EscapeString(s)
+}
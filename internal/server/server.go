@@ -1,22 +1,23 @@
 package server
 
 import (
+	"go.mod/internal/config"
 	"log"
 	"net/http"
-	"time"
 )
 
 type Server struct {
 	server *http.Server
 }
 
-func (s *Server) ServerRun(handlers http.Handler, port string) error {
+func (s *Server) ServerRun(handlers http.Handler, cfg config.ServerConfig) error {
 	s.server = &http.Server{
-		Addr:         "localhost:" + port,
+		Addr:         cfg.Addr(),
 		Handler:      handlers,
-		ReadTimeout:  10 * time.Second, // 10secont patom zakroetsa
-		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
 	}
-	log.Println("server is running on localhost:" + port)
+	log.Println("server is running on " + cfg.Addr())
 	return s.server.ListenAndServe()
 }
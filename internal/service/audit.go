@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+)
+
+// Размер страницы журнала аудита по умолчанию и максимальный
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 500
+)
+
+// AuditService — чтение журнала аудита
+type AuditService struct {
+	audit  database.AuditRepository
+	fields *FieldPolicy
+}
+
+func NewAuditService(audit database.AuditRepository, fields *FieldPolicy) *AuditService {
+	return &AuditService{audit: audit, fields: fields}
+}
+
+// Журнал аудита с фильтрами (разрешение audit:read)
+func (s *AuditService) List(actor Actor, q model.AuditQuery) (model.AuditPage, error) {
+	if !actor.Can(model.PermAuditRead) {
+		return model.AuditPage{}, forbidden("forbidden.audit_view")
+	}
+
+	if q.Limit == 0 {
+		q.Limit = DefaultAuditPageSize
+	}
+	if err := validateAuditQuery(q); err != nil {
+		return model.AuditPage{}, err
+	}
+
+	page, err := s.audit.ListAudit(q)
+	return redactAudit(s.fields.View(actor), page), mapRepoError(err)
+}
+
+// История изменений сотрудника (с разрешением employees:history); история удалённого
+// или уже окончательно удалённого сотрудника — ещё и с разрешением employees:view_deleted
+func (s *EmployeeService) History(actor Actor, id int64, limit, offset int) (model.AuditPage, error) {
+	if !actor.Can(model.PermEmployeesHistory) {
+		return model.AuditPage{}, forbidden("forbidden.history_view")
+	}
+
+	scope := actor.Scope()
+	employee, err := s.employees.GetEmployeeByID(id, true, scope)
+	switch {
+	case errors.Is(err, database.ErrNotFound) && scope.Limited:
+		// Окончательно удалённого сотрудника нельзя отнести к области доступа
+		return model.AuditPage{}, mapRepoError(err)
+	case err != nil && !errors.Is(err, database.ErrNotFound):
+		return model.AuditPage{}, mapRepoError(err)
+	case (err != nil || employee.DeletedAt != nil) && !actor.Can(model.PermEmployeesViewDeleted):
+		return model.AuditPage{}, forbidden("forbidden.employees_view_deleted")
+	}
+
+	q := model.AuditQuery{Entity: model.AuditEmployee, EntityID: id, Scope: actor.Scope(), Limit: limit, Offset: offset}
+	if q.Limit == 0 {
+		q.Limit = DefaultAuditPageSize
+	}
+	if err := validateAuditQuery(q); err != nil {
+		return model.AuditPage{}, err
+	}
+
+	page, err := s.audit.ListAudit(q)
+	return redactAudit(s.fields.View(actor), page), mapRepoError(err)
+}
+
+// redactAudit — в изменениях сотрудников нет полей, скрытых от пользователя, а маскированные замаскированы
+func redactAudit(view model.FieldView, page model.AuditPage) model.AuditPage {
+	for i, entry := range page.Items {
+		if entry.Entity == model.AuditEmployee {
+			page.Items[i].Changes = view.RedactChanges(entry.Changes)
+		}
+	}
+	return page
+}
+
+// validateAuditQuery — проверка параметров выборки журнала
+func validateAuditQuery(q model.AuditQuery) error {
+	var v validator
+	v.between("limit", q.Limit, 1, MaxAuditPageSize)
+	if q.Offset < 0 {
+		v.add("offset", "validation.negative")
+	}
+	if q.Entity != "" {
+		v.oneOf("entity", q.Entity, model.AuditEmployee, model.AuditUser, model.AuditRole, model.AuditDepartment)
+	}
+	if q.Action != "" {
+		v.oneOf("action", q.Action, model.AuditCreate, model.AuditUpdate, model.AuditDelete, model.AuditRestore, model.AuditPurge,
+			model.AuditLock, model.AuditUnlock)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		v.add("to", "validation.to_before_from")
+	}
+	return v.err()
+}
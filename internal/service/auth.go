@@ -0,0 +1,243 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"go.mod/internal/config"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"go.mod/pkg/password"
+	"log"
+	"time"
+)
+
+// AuthService — вход, выдача, обновление и отзыв токенов
+type AuthService struct {
+	users  database.UserRepository
+	roles  database.RoleRepository
+	tokens database.TokenRepository
+	jwt    config.JWTConfig
+	guard  *loginGuard
+}
+
+func NewAuthService(users database.UserRepository, roles database.RoleRepository, tokens database.TokenRepository, jwt config.JWTConfig, login config.LoginConfig) *AuthService {
+	return &AuthService{users: users, roles: roles, tokens: tokens, jwt: jwt, guard: newLoginGuard(login)}
+}
+
+// Login — проверяет логин и пароль и выдаёт новую пару токенов. Попытки сверх лимитов, во время задержки
+// после неудачи и под заблокированной учётной записью отклоняются с LimitError, пароль при этом не проверяется.
+// ip и requestID — адрес клиента (для лимита) и идентификатор запроса (для журнала аудита).
+func (s *AuthService) Login(username, plain, ip, requestID string) (model.TokenPair, error) {
+	now := time.Now()
+	if err := s.guard.allow(username, ip, now); err != nil {
+		return model.TokenPair{}, err
+	}
+
+	user, err := s.users.GetUserByUsername(username)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return model.TokenPair{}, s.guard.unknownUser(username, plain, now)
+		}
+		return model.TokenPair{}, mapRepoError(err)
+	}
+
+	// Попытка учитывается как неудачная ещё до проверки пароля (вместе с проверкой, открыт ли вход),
+	// иначе параллельные попытки успели бы проверить больше паролей, чем позволяет лимит
+	attempt, counted, err := s.users.RecordLoginAttempt(int64(user.Id), s.guard.policy)
+	if err != nil {
+		return model.TokenPair{}, mapRepoError(err)
+	}
+	if !counted {
+		return model.TokenPair{}, s.guard.locked(attempt.FailedAttempts, attempt.LockedUntil, now)
+	}
+
+	// Сравнение хешей за постоянное время
+	ok, needsRehash := password.Verify(plain, user.Password)
+	if !ok {
+		// Блокировка вступает в силу только сейчас: при верном пароле счётчик был бы сброшен
+		if s.guard.lockout(attempt.FailedAttempts) {
+			meta := model.AuditMeta{Actor: "system", RequestID: requestID}
+			if err := s.users.RecordLockout(user, attempt, meta); err != nil {
+				log.Printf("не удалось записать блокировку пользователя %s в журнал: %v", user.Username, err)
+			}
+			log.Printf("учётная запись %s заблокирована до %s после %d неудачных попыток входа (последняя с адреса %s)",
+				user.Username, attempt.LockedUntil.Format(time.RFC3339), attempt.FailedAttempts, ip)
+		}
+		return model.TokenPair{}, ErrInvalidCredentials
+	}
+	if err := s.users.ResetLoginFailures(int64(user.Id)); err != nil {
+		log.Printf("не удалось сбросить неудачные попытки входа пользователя %s: %v", user.Username, err)
+	}
+
+	// Старый пароль в открытом виде или устаревший хеш — перехешируем после успешного входа
+	if needsRehash {
+		if hash, err := password.Hash(plain); err != nil {
+			log.Printf("не удалось перехешировать пароль пользователя %s: %v", user.Username, err)
+		} else if err := s.users.UpdateUserPassword(int64(user.Id), hash); err != nil {
+			log.Printf("не удалось сохранить новый хеш пароля пользователя %s: %v", user.Username, err)
+		}
+	}
+
+	// Каждый вход начинает новое семейство refresh-токенов
+	familyID, err := randomToken(16)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+	return s.issueTokens(user, familyID)
+}
+
+// ResetLoginLimit — снова разрешает попытки входа под логином username, уже исчерпавшим лимит в окне
+// (лимит по IP-адресу не снимается); вызывается при разблокировке учётной записи
+func (s *AuthService) ResetLoginLimit(username string) {
+	s.guard.reset(username)
+}
+
+// Refresh — обмен refresh-токена на новую пару (ротация).
+// Повторное предъявление уже использованного токена отзывает всё семейство.
+func (s *AuthService) Refresh(refreshToken string) (model.TokenPair, error) {
+	old, err := s.tokens.UseRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, database.ErrTokenReused) {
+			log.Printf("повторное использование refresh-токена, семейство %s отозвано", old.FamilyId)
+		}
+		if errors.Is(err, database.ErrTokenReused) || errors.Is(err, database.ErrTokenInvalid) {
+			return model.TokenPair{}, ErrInvalidToken
+		}
+		return model.TokenPair{}, mapRepoError(err)
+	}
+
+	// Роль берём из базы заново — изменения прав применяются при следующем обновлении
+	user, err := s.users.GetUserByID(int64(old.UserId), false)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return model.TokenPair{}, ErrInvalidToken
+		}
+		return model.TokenPair{}, mapRepoError(err)
+	}
+
+	return s.issueTokens(user, old.FamilyId)
+}
+
+// Logout — отзывает семейство refresh-токена и, если передан, ещё действующий access-токен
+func (s *AuthService) Logout(refreshToken, accessToken string) error {
+	familyID, err := s.tokens.GetTokenFamily(hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, database.ErrTokenInvalid) {
+			return ErrInvalidToken
+		}
+		return mapRepoError(err)
+	}
+
+	if err := s.tokens.RevokeTokenFamily(familyID); err != nil {
+		return mapRepoError(err)
+	}
+
+	if accessToken == "" {
+		return nil
+	}
+	if claims, err := s.ParseToken(accessToken); err == nil && claims.ExpiresAt != nil {
+		return mapRepoError(s.tokens.RevokeAccessToken(claims.ID, claims.ExpiresAt.Time))
+	}
+	return nil
+}
+
+// ParseToken — проверяет подпись access-токена и что он не отозван
+func (s *AuthService) ParseToken(tokenString string) (*model.Claims, error) {
+	claims := &model.Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.jwt.Key(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	// Проверяем список отозванных токенов
+	revoked, err := s.tokens.IsTokenRevoked(claims.ID)
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// Permissions — текущие разрешения пользователя по всем его ролям. Читаются из хранилища при каждом запросе,
+// поэтому изменение разрешений роли действует сразу, без повторного входа.
+func (s *AuthService) Permissions(username string) ([]string, error) {
+	permissions, err := s.roles.GetUserPermissions(username)
+	return permissions, mapRepoError(err)
+}
+
+// issueTokens — создаёт access-токен и refresh-токен в указанном семействе
+func (s *AuthService) issueTokens(user model.User, familyID string) (model.TokenPair, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	// Создание JWT-токена
+	now := time.Now()
+	expirationTime := now.Add(s.jwt.AccessTTL)
+	claims := &model.Claims{
+		Username:     user.Username,
+		Roles:        user.Roles,
+		DepartmentId: user.DepartmentId,
+		Locale:       user.Locale,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
+	}
+
+	// Подпись токена секретным ключом
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(s.jwt.Key())
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	err = s.tokens.CreateRefreshToken(model.RefreshToken{
+		UserId:          user.Id,
+		FamilyId:        familyID,
+		TokenHash:       hashToken(refreshToken),
+		AccessJti:       jti,
+		AccessExpiresAt: expirationTime,
+		ExpiresAt:       now.Add(s.jwt.RefreshTTL),
+	})
+	if err != nil {
+		return model.TokenPair{}, mapRepoError(err)
+	}
+
+	return model.TokenPair{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.jwt.AccessTTL.Seconds()),
+	}, nil
+}
+
+// randomToken — случайная строка из n байт в base64url
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken — в базе refresh-токены хранятся только в виде SHA-256
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"strings"
+)
+
+// DepartmentService — операции над отделами
+type DepartmentService struct {
+	departments database.DepartmentRepository
+	employees   database.EmployeeRepository
+}
+
+func NewDepartmentService(departments database.DepartmentRepository, employees database.EmployeeRepository) *DepartmentService {
+	return &DepartmentService{departments: departments, employees: employees}
+}
+
+// Получить отдел по ID (разрешение departments:read проверяется на маршруте)
+func (s *DepartmentService) Get(actor Actor, id int64) (model.Department, error) {
+	department, err := s.departments.GetDepartmentByID(id)
+	return department, mapRepoError(err)
+}
+
+// Получить все отделы списком (разрешение departments:read проверяется на маршруте)
+func (s *DepartmentService) List(actor Actor) ([]model.Department, error) {
+	departments, err := s.departments.GetAllDepartments()
+	return departments, mapRepoError(err)
+}
+
+// Получить все отделы деревом: верхний уровень и вложенные подотделы
+func (s *DepartmentService) Tree(actor Actor) ([]model.DepartmentNode, error) {
+	departments, err := s.departments.GetAllDepartments()
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	children := make(map[int][]model.Department)
+	for _, d := range departments {
+		if d.ParentId != nil {
+			children[*d.ParentId] = append(children[*d.ParentId], d)
+		}
+	}
+
+	var build func(d model.Department) model.DepartmentNode
+	build = func(d model.Department) model.DepartmentNode {
+		node := model.DepartmentNode{Department: d, Children: []model.DepartmentNode{}}
+		for _, child := range children[d.Id] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	roots := []model.DepartmentNode{}
+	for _, d := range departments {
+		if d.ParentId == nil {
+			roots = append(roots, build(d))
+		}
+	}
+	return roots, nil
+}
+
+// Создать отдел, возвращает его ID
+func (s *DepartmentService) Create(actor Actor, department model.Department) (int64, error) {
+	if !actor.Can(model.PermDepartmentsWrite) {
+		return 0, forbidden("forbidden.departments_create")
+	}
+
+	department.Name = strings.TrimSpace(department.Name)
+	if err := s.validateDepartment(0, department, model.Department{}); err != nil {
+		return 0, err
+	}
+
+	id, err := s.departments.CreateDepartment(department)
+	return id, mapRepoError(err)
+}
+
+// Обновить отдел (название, вышестоящий отдел, руководитель)
+func (s *DepartmentService) Update(actor Actor, id int64, department model.Department) error {
+	if !actor.Can(model.PermDepartmentsWrite) {
+		return forbidden("forbidden.departments_update")
+	}
+
+	current, err := s.departments.GetDepartmentByID(id)
+	if err != nil {
+		return mapRepoError(err)
+	}
+	department.Name = strings.TrimSpace(department.Name)
+	if err := s.validateDepartment(id, department, current); err != nil {
+		return err
+	}
+
+	err = s.departments.UpdateDepartment(id, department)
+	if errors.Is(err, database.ErrCycle) {
+		return fieldError("parent_id", "validation.department_cycle")
+	}
+	return mapRepoError(err)
+}
+
+// Удалить отдел; отдел с подотделами или сотрудниками удалить нельзя
+func (s *DepartmentService) Delete(actor Actor, id int64) error {
+	if !actor.Can(model.PermDepartmentsWrite) {
+		return forbidden("forbidden.departments_delete")
+	}
+
+	return mapRepoError(s.departments.DeleteDepartment(id))
+}
+
+// validateDepartment — поля отдела и существование вышестоящего отдела и руководителя.
+// Прежний руководитель (из current) не проверяется: его удаление не должно мешать менять отдел.
+func (s *DepartmentService) validateDepartment(id int64, d, current model.Department) error {
+	var v validator
+	v.required("name", d.Name)
+	v.maxLen("name", d.Name, 100)
+
+	if d.ParentId != nil {
+		if int64(*d.ParentId) == id {
+			v.add("parent_id", "validation.department_self_parent")
+		} else if _, err := s.departments.GetDepartmentByID(int64(*d.ParentId)); errors.Is(err, database.ErrNotFound) {
+			v.add("parent_id", "validation.department_not_found")
+		} else if err != nil {
+			return err
+		}
+	}
+	if d.HeadId != nil && !sameID(d.HeadId, current.HeadId) {
+		if _, err := s.employees.GetEmployeeByID(int64(*d.HeadId), false, model.Scope{}); errors.Is(err, database.ErrNotFound) {
+			v.add("head_id", "validation.employee_not_found")
+		} else if err != nil {
+			return err
+		}
+	}
+	return v.err()
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"errors"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"strings"
+	"unicode"
+)
+
+// EmployeeService — операции над сотрудниками
+type EmployeeService struct {
+	employees   database.EmployeeRepository
+	departments database.DepartmentRepository
+	audit       database.AuditRepository
+	fields      *FieldPolicy
+}
+
+func NewEmployeeService(employees database.EmployeeRepository, departments database.DepartmentRepository, audit database.AuditRepository, fields *FieldPolicy) *EmployeeService {
+	return &EmployeeService{employees: employees, departments: departments, audit: audit, fields: fields}
+}
+
+// Получить сотрудника по ID (удалённого — с разрешением employees:view_deleted)
+func (s *EmployeeService) Get(actor Actor, id int64, includeDeleted bool) (model.Employee, error) {
+	if includeDeleted && !actor.Can(model.PermEmployeesViewDeleted) {
+		return model.Employee{}, forbidden("forbidden.employees_view_deleted")
+	}
+
+	employee, err := s.employees.GetEmployeeByID(id, includeDeleted, actor.Scope())
+	return s.fields.View(actor).Redact(employee), mapRepoError(err)
+}
+
+// FieldView — какие поля сотрудника пользователь видит полностью, маскированными или не видит вовсе
+func (s *EmployeeService) FieldView(actor Actor) model.FieldView {
+	return s.fields.View(actor)
+}
+
+// Размер страницы списка по умолчанию и максимальный
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// Получить страницу списка сотрудников из области доступа пользователя
+func (s *EmployeeService) List(actor Actor, q model.EmployeeQuery) (model.EmployeePage, error) {
+	if q.IncludeDeleted && !actor.Can(model.PermEmployeesViewDeleted) {
+		return model.EmployeePage{}, forbidden("forbidden.employees_view_deleted")
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	view := s.fields.View(actor)
+	if err := validateEmployeeQuery(q, view); err != nil {
+		return model.EmployeePage{}, err
+	}
+
+	q.Scope = actor.Scope()
+	page, err := s.employees.ListEmployees(q)
+	if errors.Is(err, database.ErrInvalidCursor) {
+		return page, fieldError("cursor", "validation.cursor")
+	}
+	page.Items = redactEmployees(view, page.Items)
+	return page, mapRepoError(err)
+}
+
+// Размер выдачи поиска по умолчанию и максимальный
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// Поиск сотрудников по строке q, результаты отсортированы по релевантности
+func (s *EmployeeService) Search(actor Actor, q string, limit int) ([]model.EmployeeSearchResult, error) {
+	q = strings.TrimSpace(q)
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+
+	var v validator
+	if len([]rune(q)) < 2 || !strings.ContainsFunc(q, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+		v.add("q", "validation.search_query", i18n.Args{"count": 2})
+	}
+	v.maxLen("q", q, 200)
+	v.between("limit", limit, 1, MaxSearchLimit)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	// Поиск идёт только по полям, которые пользователь видит полностью, иначе по совпадениям можно было бы
+	// угадать скрытое значение
+	view := s.fields.View(actor)
+	results, err := s.employees.SearchEmployees(q, limit, actor.Scope(), view)
+	for i := range results {
+		results[i].Employee = view.Redact(results[i].Employee)
+	}
+	return results, mapRepoError(err)
+}
+
+// Создать сотрудника, возвращает его ID
+func (s *EmployeeService) Create(actor Actor, employee model.Employee) (int64, error) {
+	if !actor.Can(model.PermEmployeesCreate) {
+		return 0, forbidden("forbidden.employees_create")
+	}
+
+	employee = normalizeEmployee(employee)
+	if err := validateEmployee(employee, nil); err != nil {
+		return 0, err
+	}
+	employee, err := s.resolveDepartment(employee)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.checkDepartmentScope(actor, employee.DepartmentId); err != nil {
+		return 0, err
+	}
+	if err := s.checkManager(actor, 0, employee, nil); err != nil {
+		return 0, err
+	}
+
+	id, err := s.employees.CreateEmployee(employee, actor.meta())
+	return id, mapRepoError(err)
+}
+
+// Обновить данные сотрудника, если его версия всё ещё равна version (0 — без проверки).
+// Поля, которые пользователь не видит полностью, сохраняют прежние значения. Возвращает запись после изменения.
+func (s *EmployeeService) Update(actor Actor, id int64, version int, employee model.Employee) (model.Employee, error) {
+	if !actor.Can(model.PermEmployeesUpdate) {
+		return model.Employee{}, forbidden("forbidden.employees_update")
+	}
+
+	current, err := s.employees.GetEmployeeByID(id, false, actor.Scope())
+	if err != nil {
+		return model.Employee{}, mapRepoError(err)
+	}
+	view := s.fields.View(actor)
+	employee = view.Keep(employee, current)
+
+	employee = keepPhone(normalizeEmployee(employee), current)
+	if err := validateEmployee(employee, &current); err != nil {
+		return model.Employee{}, err
+	}
+	employee, err = s.resolveDepartment(employee)
+	if err != nil {
+		return model.Employee{}, err
+	}
+	if err := s.checkDepartmentScope(actor, employee.DepartmentId); err != nil {
+		return model.Employee{}, err
+	}
+	if err := s.checkManager(actor, id, employee, current.ManagerId); err != nil {
+		return model.Employee{}, err
+	}
+
+	updated, err := s.employees.UpdateEmployee(id, version, employee, actor.Scope(), actor.meta())
+	if errors.Is(err, database.ErrCycle) {
+		return model.Employee{}, fieldError("manager_id", "validation.manager_cycle")
+	}
+	if errors.Is(err, database.ErrStatusTransition) {
+		return model.Employee{}, s.transitionError(id, employee.Status)
+	}
+	return view.Redact(updated), mapRepoError(err)
+}
+
+// Удалить сотрудника (мягко, его можно восстановить до окончательной очистки),
+// если его версия всё ещё равна version (0 — без проверки)
+func (s *EmployeeService) Delete(actor Actor, id int64, version int) error {
+	if !actor.Can(model.PermEmployeesDelete) {
+		return forbidden("forbidden.employees_delete")
+	}
+
+	return mapRepoError(s.employees.DeleteEmployee(id, version, actor.Scope(), actor.meta()))
+}
+
+// Восстановить удалённого сотрудника
+func (s *EmployeeService) Restore(actor Actor, id int64) error {
+	if !actor.Can(model.PermEmployeesDelete) {
+		return forbidden("forbidden.employees_restore")
+	}
+
+	return mapRepoError(s.employees.RestoreEmployee(id, actor.Scope(), actor.meta()))
+}
+
+// resolveDepartment — отдел по department_id, а если он не указан — по названию отдела
+func (s *EmployeeService) resolveDepartment(e model.Employee) (model.Employee, error) {
+	var department model.Department
+	var err error
+	field := "department_id"
+	switch {
+	case e.DepartmentId != nil:
+		department, err = s.departments.GetDepartmentByID(int64(*e.DepartmentId))
+	case e.Department != "":
+		field = "department"
+		department, err = s.departments.GetDepartmentByName(e.Department)
+	default:
+		return e, nil
+	}
+	if errors.Is(err, database.ErrNotFound) {
+		return e, fieldError(field, "validation.department_not_found")
+	}
+	if err != nil {
+		return e, mapRepoError(err)
+	}
+
+	e.DepartmentId = &department.Id
+	e.Department = department.Name
+	return e, nil
+}
+
+// checkManager — руководитель существует, доступен actor и не совпадает с самим сотрудником.
+// Руководитель ищется в области доступа actor: для сотрудника вне её ответ тот же, что и для
+// несуществующего, чтобы по ошибке нельзя было узнать, какие ID заняты в чужих отделах.
+// Прежний руководитель current не проверяется: если его удалили или он вне области доступа,
+// это не должно мешать менять другие поля сотрудника (ссылка на него снимается при окончательном удалении).
+func (s *EmployeeService) checkManager(actor Actor, id int64, e model.Employee, current *int) error {
+	if e.ManagerId == nil || sameID(e.ManagerId, current) {
+		return nil
+	}
+	if int64(*e.ManagerId) == id {
+		return fieldError("manager_id", "validation.manager_self")
+	}
+	if _, err := s.employees.GetEmployeeByID(int64(*e.ManagerId), false, actor.Scope()); errors.Is(err, database.ErrNotFound) {
+		return fieldError("manager_id", "validation.employee_not_found")
+	} else if err != nil {
+		return mapRepoError(err)
+	}
+	return nil
+}
+
+// normalizeEmployee — убирает лишние пробелы по краям строковых полей
+func normalizeEmployee(e model.Employee) model.Employee {
+	e.LastName = strings.TrimSpace(e.LastName)
+	e.FirstName = strings.TrimSpace(e.FirstName)
+	e.MiddleName = strings.TrimSpace(e.MiddleName)
+	e.Position = strings.TrimSpace(e.Position)
+	e.Department = strings.TrimSpace(e.Department)
+	e.Email = strings.TrimSpace(e.Email)
+	e.PhoneNumber = normalizePhone(strings.TrimSpace(e.PhoneNumber))
+	e.Status = strings.TrimSpace(e.Status)
+	e.PhotoUrl = strings.TrimSpace(e.PhotoUrl)
+	return e
+}
+
+// keepPhone — если номер отличается от сохранённого только записью, оставляет сохранённый
+func keepPhone(e, current model.Employee) model.Employee {
+	if e.PhoneNumber == normalizePhone(strings.TrimSpace(current.PhoneNumber)) {
+		e.PhoneNumber = current.PhoneNumber
+	}
+	return e
+}
+
+// validateEmployee — ограничения полей заданы тегами validate модели.
+// current — запись до изменения (nil при создании): номер телефона проверяется, только если он изменился,
+// чтобы номера, сохранённые до появления проверки формата, не мешали править остальные поля
+func validateEmployee(e model.Employee, current *model.Employee) error {
+	var v validator
+	v.check(e)
+	if current != nil && e.PhoneNumber == current.PhoneNumber {
+		delete(v.fields, "phonenumber")
+	}
+	if !e.HireDate.IsZero() {
+		if !e.ProbationEndDate.IsZero() && e.ProbationEndDate.Before(e.HireDate) {
+			v.add("probation_end_date", "validation.before_hire_date")
+		}
+		if !e.TerminationDate.IsZero() && e.TerminationDate.Before(e.HireDate) {
+			v.add("termination_date", "validation.before_hire_date")
+		}
+	}
+	return v.err()
+}
+
+// validateEmployeeQuery — проверка параметров выборки списка
+func validateEmployeeQuery(q model.EmployeeQuery, view model.FieldView) error {
+	var v validator
+	v.between("limit", q.Limit, 1, MaxPageSize)
+	if q.DepartmentID < 0 {
+		v.add("department_id", "validation.department_id")
+	}
+	if q.Subdepartments && q.DepartmentID == 0 {
+		v.add("subdepartments", "validation.subdepartments")
+	}
+	if q.Offset < 0 {
+		v.add("offset", "validation.negative")
+	}
+	if q.Cursor != "" && q.Offset > 0 {
+		v.add("cursor", "validation.cursor_with_offset")
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range q.Sort {
+		if !model.EmployeeSortFields[f.Field] {
+			v.add("sort", "validation.sort_unavailable", i18n.Args{"field": f.Field})
+		} else if seen[f.Field] {
+			v.add("sort", "validation.sort_duplicate", i18n.Args{"field": f.Field})
+		} else {
+			v.visible("sort", view, f.Field)
+		}
+		seen[f.Field] = true
+	}
+
+	if len(q.Status) > 0 {
+		v.visible("status", view, "status")
+	}
+	if q.HiredFrom != "" {
+		v.visible("hired_from", view, "hiredate")
+	}
+	if q.HiredTo != "" {
+		v.visible("hired_to", view, "hiredate")
+	}
+	v.date("hired_from", q.HiredFrom)
+	v.date("hired_to", q.HiredTo)
+	return v.err()
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go.mod/internal/database"
+	"go.mod/internal/messages"
+	"go.mod/pkg/i18n"
+	"sort"
+	"strings"
+)
+
+// Доменные ошибки (проверяются через errors.Is)
+var (
+	ErrNotFound           = errors.New("не найдено")
+	ErrConflict           = errors.New("конфликт данных")
+	ErrPreconditionFailed = errors.New("запись изменилась с момента получения")
+	ErrForbidden          = errors.New("доступ запрещён")
+	ErrValidation         = errors.New("некорректные данные")
+	ErrInvalidCredentials = errors.New("неверный логин или пароль")
+	ErrInvalidToken       = errors.New("недействительный токен")
+	ErrTooManyAttempts    = errors.New("слишком много попыток входа")
+	ErrUnavailable        = errors.New("сервис временно недоступен")
+)
+
+// ValidationError — ошибки проверки входных данных по полям
+type ValidationError struct {
+	Fields map[string]i18n.Text // Поле -> сообщение об ошибке
+}
+
+// fieldError — ошибка проверки одного поля
+func fieldError(field, key string, args ...i18n.Args) error {
+	return &ValidationError{Fields: map[string]i18n.Text{field: i18n.T(key, args...)}}
+}
+
+func (e *ValidationError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+": "+messages.Default(e.Fields[name]))
+	}
+	return ErrValidation.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Localized — ошибка с сообщением из каталога, которое можно показать клиенту на его языке
+type Localized interface {
+	Text() i18n.Text
+}
+
+// domainError — ошибка хранилища, помеченная доменным видом ошибки и сообщением для клиента
+type domainError struct {
+	kind error
+	err  error
+	text i18n.Text
+}
+
+func (e *domainError) Error() string {
+	return e.err.Error()
+}
+
+func (e *domainError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func (e *domainError) Text() i18n.Text {
+	return e.text
+}
+
+// forbidden — ErrForbidden с пояснением из каталога сообщений
+func forbidden(key string, args ...i18n.Args) error {
+	text := i18n.T(key, args...)
+	return &domainError{kind: ErrForbidden, err: fmt.Errorf("%s: %s", ErrForbidden, messages.Default(text)), text: text}
+}
+
+// mapRepoError — переводит ошибки хранилища в доменные
+func mapRepoError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, database.ErrNotFound):
+		return &domainError{kind: ErrNotFound, err: err, text: i18n.T("error.not_found")}
+	case errors.Is(err, database.ErrDuplicate):
+		return &domainError{kind: ErrConflict, err: err, text: i18n.T("error.duplicate")}
+	case errors.Is(err, database.ErrReferenced):
+		return &domainError{kind: ErrConflict, err: err, text: i18n.T("error.referenced")}
+	case errors.Is(err, database.ErrVersionMismatch):
+		return &domainError{kind: ErrPreconditionFailed, err: err, text: i18n.T("error.precondition_failed")}
+	case errors.Is(err, database.ErrTimeout), errors.Is(err, database.ErrUnavailable):
+		return &domainError{kind: ErrUnavailable, err: err, text: i18n.T("error.unavailable")}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"go.mod/internal/config"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+)
+
+// FieldPolicy — видимость полей сотрудника по ролям (config.FieldsConfig)
+type FieldPolicy struct {
+	defaults model.FieldView
+	roles    map[string]model.FieldView
+}
+
+func NewFieldPolicy(cfg config.FieldsConfig) *FieldPolicy {
+	p := &FieldPolicy{defaults: fieldView(cfg.Default), roles: make(map[string]model.FieldView, len(cfg.Roles))}
+	for role, policy := range cfg.Roles {
+		p.roles[role] = fieldView(policy)
+	}
+	return p
+}
+
+// View — видимость полей для пользователя: по каждому полю — самая открытая из его ролей,
+// а если ни одна роль не описана в политике — политика по умолчанию
+func (p *FieldPolicy) View(actor Actor) model.FieldView {
+	var views []model.FieldView
+	for _, role := range actor.Roles {
+		if view, ok := p.roles[role]; ok {
+			views = append(views, view)
+		}
+	}
+	if len(views) == 0 {
+		return p.defaults
+	}
+
+	merged := make(model.FieldView)
+	for _, field := range model.RestrictedEmployeeFields {
+		access := model.FieldHidden
+		for _, view := range views {
+			access = min(access, view.Access(field))
+		}
+		if access != model.FieldVisible {
+			merged[field] = access
+		}
+	}
+	return merged
+}
+
+func fieldView(policy config.FieldPolicy) model.FieldView {
+	view := make(model.FieldView)
+	for _, field := range policy.Masked {
+		view[field] = model.FieldMasked
+	}
+	for _, field := range policy.Hidden {
+		view[field] = model.FieldHidden
+	}
+	return view
+}
+
+// redactEmployees — сотрудники в том виде, в каком их видит пользователь
+func redactEmployees(view model.FieldView, employees []model.Employee) []model.Employee {
+	for i := range employees {
+		employees[i] = view.Redact(employees[i])
+	}
+	return employees
+}
+
+// visible — пользователь видит поля fields полностью: фильтр или сортировка по скрытому
+// или маскированному полю позволили бы узнать его значение
+func (v *validator) visible(param string, view model.FieldView, fields ...string) {
+	for _, field := range fields {
+		if !view.Visible(field) {
+			v.add(param, "validation.field_hidden", i18n.Args{"field": field})
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"log"
+	"strings"
+	"time"
+)
+
+// GetAsOf — сотрудник с должностью, отделом и занятостью на дату по кадровой истории
+// Не принятый к этой дате сотрудник не найден.
+func (s *EmployeeService) GetAsOf(actor Actor, id int64, date model.Date) (model.Employee, error) {
+	employee, err := s.employees.GetEmployeeAsOf(id, date, actor.Scope())
+	return s.fields.View(actor).Redact(employee), mapRepoError(err)
+}
+
+// JobHistory — кадровая история сотрудника, включая действия с будущей датой
+func (s *EmployeeService) JobHistory(actor Actor, id int64) ([]model.JobAssignment, error) {
+	if !actor.Can(model.PermEmployeesHistory) {
+		return nil, forbidden("forbidden.job_history_view")
+	}
+
+	history, err := s.employees.GetJobHistory(id, actor.Scope())
+	return history, mapRepoError(err)
+}
+
+// AddAssignment — кадровое действие над сотрудником: приём, перевод, повышение, понижение, увольнение или повторный приём.
+// Не указанные должность и отдел остаются прежними. Действие с датой не позже сегодняшней сразу меняет запись сотрудника,
+// с будущей датой — вступает в силу автоматически (см. ApplyAssignments).
+func (s *EmployeeService) AddAssignment(actor Actor, id int64, a model.JobAssignment) (model.JobAssignment, error) {
+	if !actor.Can(model.PermEmployeesUpdate) {
+		return model.JobAssignment{}, forbidden("forbidden.employees_update")
+	}
+
+	a.EmployeeId = id
+	a.Action = strings.TrimSpace(a.Action)
+	a.Position = strings.TrimSpace(a.Position)
+	a.Reason = strings.TrimSpace(a.Reason)
+
+	var v validator
+	v.required("action", a.Action)
+	if a.Action != "" {
+		v.oneOf("action", a.Action, model.JobActions...)
+	}
+	if a.EffectiveDate.IsZero() {
+		v.add("effective_date", "validation.required")
+	}
+	v.maxLen("position", a.Position, 100)
+	v.maxLen("reason", a.Reason, 500)
+	if err := v.err(); err != nil {
+		return model.JobAssignment{}, err
+	}
+
+	current, err := s.employees.GetEmployeeByID(id, false, actor.Scope())
+	if err != nil {
+		return model.JobAssignment{}, mapRepoError(err)
+	}
+	if err := jobActionAllowed(a.Action, current.Status); err != nil {
+		return model.JobAssignment{}, err
+	}
+	if a.Action == model.JobTermination && !current.HireDate.IsZero() && a.EffectiveDate.Before(current.HireDate) {
+		return model.JobAssignment{}, fieldError("effective_date", "validation.before_hire_date")
+	}
+
+	if a.Position == "" {
+		a.Position = current.Position
+	}
+	if a.DepartmentId == nil {
+		a.DepartmentId = current.DepartmentId
+	} else if _, err := s.resolveDepartment(model.Employee{DepartmentId: a.DepartmentId}); err != nil {
+		return model.JobAssignment{}, err
+	}
+	if err := s.checkDepartmentScope(actor, a.DepartmentId); err != nil {
+		return model.JobAssignment{}, err
+	}
+
+	meta := actor.meta()
+	meta.Reason = a.Reason
+	created, err := s.employees.CreateJobAssignment(a, model.Today(), meta)
+	return created, mapRepoError(err)
+}
+
+// jobActionAllowed — действие допустимо при текущем статусе: принять можно кандидата, повторно принять — уволенного,
+// остальные действия — только над работающим сотрудником
+func jobActionAllowed(action, status string) error {
+	var ok bool
+	switch action {
+	case model.JobHire:
+		ok = status == model.StatusCandidate
+	case model.JobRehire:
+		ok = status == model.StatusTerminated
+	default:
+		ok = model.Employed(status)
+	}
+	if !ok {
+		return fieldError("action", "validation.job_action_status", i18n.Args{"action": action, "status": status})
+	}
+	return nil
+}
+
+// ApplyAssignments — переносит в записи сотрудников кадровые действия, вступившие в силу к сегодняшнему дню
+func (s *EmployeeService) ApplyAssignments() (int, error) {
+	count, err := s.employees.ApplyJobAssignments(model.Today(), model.AuditMeta{Actor: "system"})
+	return count, mapRepoError(err)
+}
+
+// ApplyAssignmentsEvery — применяет вступившие в силу кадровые действия при запуске и затем каждые interval.
+// Не возвращает управление; запускается в отдельной горутине.
+func (s *EmployeeService) ApplyAssignmentsEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := s.ApplyAssignments(); err != nil {
+			log.Printf("ошибка применения кадровых действий: %v", err)
+		} else if count > 0 {
+			log.Printf("Применено кадровых действий: %d", count)
+		}
+		<-ticker.C
+	}
+}
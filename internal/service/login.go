@@ -0,0 +1,147 @@
+package service
+
+import (
+	"go.mod/internal/config"
+	"go.mod/internal/messages"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"go.mod/pkg/password"
+	"go.mod/pkg/ratelimit"
+	"math"
+	"sync"
+	"time"
+)
+
+// LimitError — попытка входа отклонена до проверки пароля; повторить можно через RetryAfter (заголовок Retry-After)
+type LimitError struct {
+	RetryAfter time.Duration
+	text       i18n.Text
+}
+
+func (e *LimitError) Error() string {
+	return ErrTooManyAttempts.Error() + ": " + messages.Default(e.text)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+func (e *LimitError) Text() i18n.Text {
+	return e.text
+}
+
+// limitError — отказ с сообщением key; в сообщение передаётся число секунд до повтора
+func limitError(key string, wait time.Duration) error {
+	seconds := int(math.Ceil(wait.Seconds()))
+	return &LimitError{RetryAfter: time.Duration(seconds) * time.Second, text: i18n.T(key, i18n.Args{"count": max(seconds, 1)})}
+}
+
+// loginGuard — защита входа от подбора пароля: лимиты попыток на логин и на IP-адрес в скользящем окне
+// и задержки с блокировкой по неудачным попыткам (model.LoginPolicy). Для существующих пользователей
+// неудачные попытки хранятся в базе, для несуществующих логинов — в памяти, чтобы ответы для них
+// (в том числе время ответа) не отличались и по ним нельзя было узнать, есть ли такой пользователь.
+type loginGuard struct {
+	policy model.LoginPolicy
+	byUser *ratelimit.Window
+	byIP   *ratelimit.Window
+
+	mu        sync.Mutex
+	unknown   map[string]unknownLogin // Неудачные попытки под несуществующими логинами
+	lastSweep time.Time
+
+	// Хеш, с которым сравнивается пароль несуществующего пользователя: проверка занимает то же время
+	dummyHash func() string
+}
+
+type unknownLogin struct {
+	failures    int
+	lockedUntil time.Time
+}
+
+func newLoginGuard(cfg config.LoginConfig) *loginGuard {
+	return &loginGuard{
+		policy:  cfg.Policy(),
+		byUser:  ratelimit.NewWindow(cfg.UserLimit, cfg.Window),
+		byIP:    ratelimit.NewWindow(cfg.IPLimit, cfg.Window),
+		unknown: make(map[string]unknownLogin),
+		dummyHash: sync.OnceValue(func() string {
+			hash, _ := password.Hash("no such user")
+			return hash
+		}),
+	}
+}
+
+// allow — попытка укладывается в лимиты на IP-адрес и на логин (и учитывается в них)
+func (g *loginGuard) allow(username, ip string, now time.Time) error {
+	if ok, wait := g.byIP.Allow(ip, now); !ok {
+		return limitError("error.login_rate_limited", wait)
+	}
+	if ok, wait := g.byUser.Allow(username, now); !ok {
+		return limitError("error.login_rate_limited", wait)
+	}
+	return nil
+}
+
+// reset — снимает с логина username лимит попыток в окне (после разблокировки учётной записи)
+func (g *loginGuard) reset(username string) {
+	g.byUser.Reset(username)
+}
+
+// locked — вход закрыт до until после failures неудачных попыток: блокировка учётной записи или задержка
+func (g *loginGuard) locked(failures int, until *time.Time, now time.Time) error {
+	if until == nil || !now.Before(*until) {
+		return nil
+	}
+	if g.lockout(failures) {
+		return limitError("error.account_locked", until.Sub(now))
+	}
+	return limitError("error.login_delayed", until.Sub(now))
+}
+
+// lockout — после failures неудачных попыток учётная запись заблокирована
+func (g *loginGuard) lockout(failures int) bool {
+	return g.policy.MaxAttempts > 0 && failures >= g.policy.MaxAttempts
+}
+
+// unknownUser — попытка входа под несуществующим логином: неудача учитывается так же, как у существующего
+// пользователя (до проверки пароля), а пароль сравнивается с фиктивным хешем
+func (g *loginGuard) unknownUser(username, plain string, now time.Time) error {
+	if err := g.countUnknown(username, now); err != nil {
+		return err
+	}
+	password.Verify(plain, g.dummyHash())
+	return ErrInvalidCredentials
+}
+
+// countUnknown — учитывает неудачную попытку под несуществующим логином, если вход под ним открыт
+func (g *loginGuard) countUnknown(username string, now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state := g.unknown[username]
+	if err := g.locked(state.failures, &state.lockedUntil, now); err != nil {
+		return err
+	}
+	g.sweep(now)
+	if g.lockout(state.failures) {
+		state.failures = 0
+	}
+	state.failures++
+	state.lockedUntil, _ = g.policy.LockedUntil(state.failures, now)
+	g.unknown[username] = state
+	return nil
+}
+
+// sweep — раз в период блокировки забывает логины, вход под которыми давно открыт
+func (g *loginGuard) sweep(now time.Time) {
+	period := max(g.policy.LockoutDuration, g.policy.MaxDelay)
+	if now.Sub(g.lastSweep) < period {
+		return
+	}
+	g.lastSweep = now
+	for username, state := range g.unknown {
+		if state.lockedUntil.Before(now.Add(-period)) {
+			delete(g.unknown, username)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"go.mod/internal/model"
+	"strings"
+)
+
+// Подчинённость и оргструктура (разрешение employees:read проверяется на маршруте).
+// Сотрудники вне области доступа пользователя в результаты не попадают.
+
+// Непосредственные подчинённые сотрудника
+func (s *EmployeeService) DirectReports(actor Actor, id int64) ([]model.Employee, error) {
+	scope := actor.Scope()
+	if _, err := s.employees.GetEmployeeByID(id, false, scope); err != nil {
+		return nil, mapRepoError(err)
+	}
+	reports, err := s.employees.GetDirectReports(id, scope)
+	return redactEmployees(s.fields.View(actor), reports), mapRepoError(err)
+}
+
+// Цепочка руководителей сотрудника: от непосредственного до верхнего
+func (s *EmployeeService) ManagerChain(actor Actor, id int64) ([]model.Employee, error) {
+	scope := actor.Scope()
+	if _, err := s.employees.GetEmployeeByID(id, false, scope); err != nil {
+		return nil, mapRepoError(err)
+	}
+	chain, err := s.employees.GetManagerChain(id, scope)
+	return redactEmployees(s.fields.View(actor), chain), mapRepoError(err)
+}
+
+// Сотрудник и все его подчинённые деревом
+func (s *EmployeeService) Subordinates(actor Actor, id int64) (model.OrgChartNode, error) {
+	scope := actor.Scope()
+	employee, err := s.employees.GetEmployeeByID(id, false, scope)
+	if err != nil {
+		return model.OrgChartNode{}, mapRepoError(err)
+	}
+	subordinates, err := s.employees.GetSubordinates(id, scope)
+	if err != nil {
+		return model.OrgChartNode{}, mapRepoError(err)
+	}
+	return buildOrgChart(append([]model.Employee{employee}, subordinates...), id)[0], nil
+}
+
+// Оргструктура: rootID = 0 — вся компания (все сотрудники без руководителя), иначе — поддерево сотрудника
+func (s *EmployeeService) OrgChart(actor Actor, rootID int64) ([]model.OrgChartNode, error) {
+	if rootID != 0 {
+		node, err := s.Subordinates(actor, rootID)
+		if err != nil {
+			return nil, err
+		}
+		return []model.OrgChartNode{node}, nil
+	}
+
+	employees, err := s.employees.GetAllEmployees(actor.Scope())
+	if err != nil {
+		return nil, mapRepoError(err)
+	}
+	return buildOrgChart(employees, 0), nil
+}
+
+// buildOrgChart — дерево из списка сотрудников. Корни — сотрудник rootID, а при rootID = 0 — все,
+// у кого нет руководителя или руководитель не входит в список (удалён). Порядок подчинённых сохраняется.
+func buildOrgChart(employees []model.Employee, rootID int64) []model.OrgChartNode {
+	listed := make(map[int]bool, len(employees))
+	for _, e := range employees {
+		listed[e.Id] = true
+	}
+
+	reports := make(map[int][]model.Employee)
+	for _, e := range employees {
+		if e.ManagerId != nil {
+			reports[*e.ManagerId] = append(reports[*e.ManagerId], e)
+		}
+	}
+
+	var build func(e model.Employee) model.OrgChartNode
+	build = func(e model.Employee) model.OrgChartNode {
+		node := model.OrgChartNode{
+			Id:         e.Id,
+			Name:       strings.TrimSpace(e.LastName + " " + e.FirstName),
+			Position:   e.Position,
+			Department: e.Department,
+			Reports:    []model.OrgChartNode{},
+		}
+		for _, report := range reports[e.Id] {
+			node.Reports = append(node.Reports, build(report))
+		}
+		return node
+	}
+
+	roots := []model.OrgChartNode{}
+	for _, e := range employees {
+		if (rootID == 0 && (e.ManagerId == nil || !listed[*e.ManagerId])) || int64(e.Id) == rootID {
+			roots = append(roots, build(e))
+		}
+	}
+	return roots
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"go.mod/pkg/jsonpatch"
+	"reflect"
+	"strings"
+)
+
+// Форматы частичного обновления
+const (
+	PatchMerge = "merge" // JSON Merge Patch (RFC 7396)
+	PatchJSON  = "json"  // JSON Patch (RFC 6902)
+)
+
+// Patch — частичное обновление сотрудника. Патч применяется к текущей записи, проверки выполняются
+// для итогового результата, а в базе обновляются только изменившиеся колонки.
+// Патч применяется к записи в том виде, в каком её видит пользователь (с проверками test только по видимым
+// значениям), а поля, которые он не видит полностью, сохраняют прежние значения.
+func (s *EmployeeService) Patch(actor Actor, id int64, version int, format string, patch []byte) (model.Employee, error) {
+	if !actor.Can(model.PermEmployeesUpdate) {
+		return model.Employee{}, forbidden("forbidden.employees_update")
+	}
+
+	scope := actor.Scope()
+	current, err := s.employees.GetEmployeeByID(id, false, scope)
+	if err != nil {
+		return model.Employee{}, mapRepoError(err)
+	}
+	// Патч к устаревшей версии не применяем, чтобы не проверять заведомо неактуальный результат
+	if version != 0 && version != current.Version {
+		return model.Employee{}, mapRepoError(fmt.Errorf("сотрудник с id %d уже изменён: %w", id, database.ErrVersionMismatch))
+	}
+
+	view := s.fields.View(actor)
+	patched, err := applyPatch(view.Redact(current), format, patch)
+	if err != nil {
+		return model.Employee{}, err
+	}
+	patched = view.Keep(patched, current)
+
+	// Отдел определяется по department_id, а если изменилось только название — по названию
+	switch {
+	case !sameID(patched.DepartmentId, current.DepartmentId):
+		patched.Department = ""
+	case patched.Department != current.Department:
+		patched.DepartmentId = nil
+	}
+
+	patched = statusDates(current, keepPhone(normalizeEmployee(patched), current))
+	if !model.CanTransition(current.Status, patched.Status) {
+		return model.Employee{}, transitionError(current.Status, patched.Status)
+	}
+	if err := validateEmployee(patched, &current); err != nil {
+		return model.Employee{}, err
+	}
+	if patched, err = s.resolveDepartment(patched); err != nil {
+		return model.Employee{}, err
+	}
+	if err := s.checkDepartmentScope(actor, patched.DepartmentId); err != nil {
+		return model.Employee{}, err
+	}
+	if err := s.checkManager(actor, id, patched, current.ManagerId); err != nil {
+		return model.Employee{}, err
+	}
+
+	// Записываем с версией прочитанной записи: если её успели изменить, результат проверок уже неактуален
+	fields := changedFields(current, patched, model.EmployeePatchFields)
+	updated, err := s.employees.PatchEmployee(id, current.Version, patched, fields, scope, actor.meta())
+	if errors.Is(err, database.ErrCycle) {
+		return model.Employee{}, fieldError("manager_id", "validation.manager_cycle")
+	}
+	if errors.Is(err, database.ErrStatusTransition) {
+		return model.Employee{}, s.transitionError(id, patched.Status)
+	}
+	return view.Redact(updated), mapRepoError(err)
+}
+
+// applyPatch — применяет патч к JSON-представлению сотрудника и разбирает результат.
+// Неизвестные поля и изменение полей только для чтения считаются ошибкой.
+func applyPatch(current model.Employee, format string, patch []byte) (model.Employee, error) {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	var result []byte
+	switch format {
+	case PatchMerge:
+		result, err = jsonpatch.MergePatch(doc, patch)
+	case PatchJSON:
+		result, err = jsonpatch.Apply(doc, patch)
+	default:
+		return model.Employee{}, fieldError("patch", "validation.patch_format", i18n.Args{"format": format})
+	}
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return model.Employee{}, &domainError{kind: ErrConflict, err: err, text: i18n.T("error.patch_test_failed")}
+	}
+	if err != nil {
+		return model.Employee{}, fieldError("patch", "validation.patch", i18n.Args{"error": err.Error()})
+	}
+
+	var patched model.Employee
+	decoder := json.NewDecoder(bytes.NewReader(result))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return model.Employee{}, fieldError("patch", "validation.patch", i18n.Args{"error": strings.TrimPrefix(err.Error(), "json: ")})
+	}
+
+	var v validator
+	if patched.Id != current.Id {
+		v.add("id", "validation.read_only")
+	}
+	if patched.Version != current.Version {
+		v.add("version", "validation.version_read_only")
+	}
+	if !reflect.DeepEqual(patched.DeletedAt, current.DeletedAt) || patched.DeletedBy != current.DeletedBy {
+		v.add("deleted_at", "validation.deleted_read_only")
+	}
+	return patched, v.err()
+}
+
+// changedFields — json-имена разрешённых полей, значения которых различаются (в порядке полей структуры)
+func changedFields(before, after interface{}, allowed map[string]bool) []string {
+	bv, av := reflect.ValueOf(before), reflect.ValueOf(after)
+	t := bv.Type()
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if allowed[name] && !reflect.DeepEqual(bv.Field(i).Interface(), av.Field(i).Interface()) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// sameID — два необязательных ID равны (оба не заданы или совпадают)
+func sameID(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
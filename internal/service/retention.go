@@ -0,0 +1,35 @@
+package service
+
+import (
+	"go.mod/internal/config"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"time"
+)
+
+// RetentionService — окончательное удаление записей, срок хранения которых после мягкого удаления истёк
+type RetentionService struct {
+	employees database.EmployeeRepository
+	users     database.UserRepository
+	retention config.RetentionConfig
+}
+
+func NewRetentionService(employees database.EmployeeRepository, users database.UserRepository, retention config.RetentionConfig) *RetentionService {
+	return &RetentionService{employees: employees, users: users, retention: retention}
+}
+
+// Purge — окончательно удаляет сотрудников и пользователей, удалённых раньше, чем retention.soft_deleted назад
+func (s *RetentionService) Purge(actor Actor) (model.PurgeResult, error) {
+	if !actor.Can(model.PermDataPurge) {
+		return model.PurgeResult{}, forbidden("forbidden.purge")
+	}
+
+	result := model.PurgeResult{Before: time.Now().Add(-s.retention.SoftDeleted)}
+
+	var err error
+	if result.Employees, err = s.employees.PurgeEmployees(result.Before, actor.meta()); err != nil {
+		return result, mapRepoError(err)
+	}
+	result.Users, err = s.users.PurgeUsers(result.Before, actor.meta())
+	return result, mapRepoError(err)
+}
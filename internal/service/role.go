@@ -0,0 +1,157 @@
+package service
+
+import (
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"regexp"
+	"strings"
+)
+
+// RoleService — роли и их разрешения (разрешение roles:manage)
+type RoleService struct {
+	roles database.RoleRepository
+}
+
+func NewRoleService(roles database.RoleRepository) *RoleService {
+	return &RoleService{roles: roles}
+}
+
+// Имя роли: латиница в нижнем регистре, цифры и подчёркивание (hr_manager)
+var roleName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Получить все роли с разрешениями
+func (s *RoleService) List(actor Actor) ([]model.Role, error) {
+	if !actor.Can(model.PermRolesManage) {
+		return nil, forbidden("forbidden.roles_manage")
+	}
+
+	roles, err := s.roles.GetRoles()
+	return roles, mapRepoError(err)
+}
+
+// Получить роль по ID
+func (s *RoleService) Get(actor Actor, id int64) (model.Role, error) {
+	if !actor.Can(model.PermRolesManage) {
+		return model.Role{}, forbidden("forbidden.roles_manage")
+	}
+
+	role, err := s.roles.GetRoleByID(id)
+	return role, mapRepoError(err)
+}
+
+// Permissions — все известные разрешения, из которых составляются роли
+func (s *RoleService) Permissions(actor Actor) ([]string, error) {
+	if !actor.Can(model.PermRolesManage) {
+		return nil, forbidden("forbidden.roles_manage")
+	}
+	return model.Permissions, nil
+}
+
+// Создать роль, возвращает её ID
+func (s *RoleService) Create(actor Actor, role model.Role) (int64, error) {
+	if !actor.Can(model.PermRolesManage) {
+		return 0, forbidden("forbidden.roles_manage")
+	}
+
+	role = normalizeRole(role)
+	if err := validateRole(role); err != nil {
+		return 0, err
+	}
+	if err := checkPermissions(actor, nil, role.Permissions); err != nil {
+		return 0, err
+	}
+
+	id, err := s.roles.CreateRole(role, actor.meta())
+	return id, mapRepoError(err)
+}
+
+// Изменить описание и разрешения роли; имя не меняется, роль администратора изменить нельзя.
+// Новые разрешения действуют для пользователей с этой ролью со следующего запроса.
+func (s *RoleService) Update(actor Actor, id int64, role model.Role) (model.Role, error) {
+	if !actor.Can(model.PermRolesManage) {
+		return model.Role{}, forbidden("forbidden.roles_manage")
+	}
+
+	current, err := s.roles.GetRoleByID(id)
+	if err != nil {
+		return model.Role{}, mapRepoError(err)
+	}
+	if current.Name == model.RoleAdmin {
+		return model.Role{}, forbidden("forbidden.role_admin")
+	}
+
+	role = normalizeRole(role)
+	if role.Name == "" {
+		role.Name = current.Name
+	}
+	if err := validateRole(role); err != nil {
+		return model.Role{}, err
+	}
+	if role.Name != current.Name {
+		return model.Role{}, fieldError("name", "validation.read_only")
+	}
+	if err := checkPermissions(actor, current.Permissions, role.Permissions); err != nil {
+		return model.Role{}, err
+	}
+
+	updated, err := s.roles.UpdateRole(id, role, actor.meta())
+	return updated, mapRepoError(err)
+}
+
+// Удалить роль; встроенную и назначенную пользователям удалить нельзя (409)
+func (s *RoleService) Delete(actor Actor, id int64) error {
+	if !actor.Can(model.PermRolesManage) {
+		return forbidden("forbidden.roles_manage")
+	}
+
+	return mapRepoError(s.roles.DeleteRole(id, actor.meta()))
+}
+
+// checkPermissions — новые разрешения роли (которых нет среди current) пользователь вправе добавить:
+// администратор — любые, остальные — только те, что есть у них самих. Иначе с разрешением roles:manage
+// можно было бы добавить своей роли любые права, и они действовали бы уже со следующего запроса
+// (то же правило, что для выдачи ролей в UserService.checkRoles).
+func checkPermissions(actor Actor, current, permissions []string) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	for _, permission := range permissions {
+		if !contains(current, permission) && !actor.Can(permission) {
+			return forbidden("forbidden.role_permission_grant", i18n.Args{"permission": permission})
+		}
+	}
+	return nil
+}
+
+// normalizeRole — убирает пробелы по краям, повторы разрешений
+func normalizeRole(r model.Role) model.Role {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.Permissions = normalizeNames(r.Permissions)
+	return r
+}
+
+// validateRole — имя роли и известные разрешения
+func validateRole(r model.Role) error {
+	var v validator
+	v.check(r)
+	if r.Name != "" && !roleName.MatchString(r.Name) {
+		v.add("name", "validation.role_name")
+	}
+	for _, p := range r.Permissions {
+		if !contains(model.Permissions, p) {
+			v.add("permissions", "validation.permission_unknown", i18n.Args{"permission": p, "values": strings.Join(model.Permissions, ", ")})
+		}
+	}
+	return v.err()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"go.mod/internal/model"
+)
+
+// Область доступа к сотрудникам (см. Actor.Scope). Чтение и изменение отдельных сотрудников ограничиваются
+// в запросах хранилища; здесь — проверки для операций, которые обращаются к сотруднику косвенно.
+
+// checkDepartmentScope — пользователь с ограниченной областью доступа может перевести сотрудника
+// только в отдел из этой области (иначе потерял бы к нему доступ)
+func (s *EmployeeService) checkDepartmentScope(actor Actor, departmentID *int) error {
+	scope := actor.Scope()
+	if !scope.Limited {
+		return nil
+	}
+	if departmentID != nil {
+		departments, err := s.departments.GetAllDepartments()
+		if err != nil {
+			return mapRepoError(err)
+		}
+		if departmentSubtree(departments, scope.DepartmentID)[int64(*departmentID)] {
+			return nil
+		}
+	}
+	return fieldError("department_id", "validation.department_out_of_scope")
+}
+
+// departmentSubtree — ID отдела и всех его подотделов
+func departmentSubtree(departments []model.Department, id int64) map[int64]bool {
+	subtree := map[int64]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for _, d := range departments {
+			if d.ParentId != nil && subtree[int64(*d.ParentId)] && !subtree[int64(d.Id)] {
+				subtree[int64(d.Id)] = true
+				changed = true
+			}
+		}
+	}
+	return subtree
+}
@@ -1,13 +1,69 @@
 package service
 
-import "go.mod/internal/database"
+import (
+	"go.mod/internal/config"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+)
 
+// Service — бизнес-логика приложения, общая для HTTP и любых других интерфейсов (CLI, gRPC)
 type Service struct {
-	database *database.Database
+	Employees   *EmployeeService
+	Departments *DepartmentService
+	Users       *UserService
+	Roles       *RoleService
+	Auth        *AuthService
+	Audit       *AuditService
+	Retention   *RetentionService
 }
 
-func NewService(db *database.Database) *Service {
+func NewService(repo *database.Repository, jwt config.JWTConfig, login config.LoginConfig, retention config.RetentionConfig, fields config.FieldsConfig) *Service {
+	policy := NewFieldPolicy(fields)
+	auth := NewAuthService(repo.Users, repo.Roles, repo.Tokens, jwt, login)
 	return &Service{
-		database: db,
+		Employees:   NewEmployeeService(repo.Employees, repo.Departments, repo.Audit, policy),
+		Departments: NewDepartmentService(repo.Departments, repo.Employees),
+		Users:       NewUserService(repo.Users, repo.Roles, repo.Departments, repo.Tokens, auth),
+		Roles:       NewRoleService(repo.Roles),
+		Auth:        auth,
+		Audit:       NewAuditService(repo.Audit, policy),
+		Retention:   NewRetentionService(repo.Employees, repo.Users, retention),
 	}
 }
+
+// Actor — пользователь, от имени которого выполняется операция
+type Actor struct {
+	Username     string
+	Roles        []string // Роли пользователя из токена (по ним выбирается видимость полей, FieldPolicy)
+	Permissions  []string // Разрешения по всем ролям пользователя (AuthService.Permissions)
+	DepartmentID *int     // Отдел пользователя из токена
+	RequestID    string   // Идентификатор запроса, попадает в журнал аудита
+}
+
+// Can — у пользователя есть разрешение permission (model.Perm*)
+func (a Actor) Can(permission string) bool {
+	return contains(a.Permissions, permission)
+}
+
+// IsAdmin — у пользователя роль администратора
+func (a Actor) IsAdmin() bool {
+	return contains(a.Roles, model.RoleAdmin)
+}
+
+// Scope — сотрудники, доступные пользователю: все с разрешением employees:all_departments,
+// иначе — только его отдела и подотделов
+func (a Actor) Scope() model.Scope {
+	if a.Can(model.PermEmployeesAllDepartments) {
+		return model.Scope{}
+	}
+	scope := model.Scope{Limited: true}
+	if a.DepartmentID != nil {
+		scope.DepartmentID = int64(*a.DepartmentID)
+	}
+	return scope
+}
+
+// meta — автор изменения для журнала аудита
+func (a Actor) meta() model.AuditMeta {
+	return model.AuditMeta{Actor: a.Username, RequestID: a.RequestID}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"strings"
+)
+
+// ChangeStatus — смена статуса сотрудника с обязательной причиной, если его версия всё ещё равна version
+// (0 — без проверки). Даты приёма и увольнения заполняются по новому статусу (см. statusDates).
+// Смена на текущий статус ничего не меняет.
+func (s *EmployeeService) ChangeStatus(actor Actor, id int64, version int, status, reason string) (model.Employee, error) {
+	if !actor.Can(model.PermEmployeesUpdate) {
+		return model.Employee{}, forbidden("forbidden.employees_update")
+	}
+	view := s.fields.View(actor)
+	if !view.Visible("status") {
+		return model.Employee{}, forbidden("forbidden.status_hidden")
+	}
+
+	status, reason = strings.TrimSpace(status), strings.TrimSpace(reason)
+	var v validator
+	v.required("status", status)
+	if status != "" {
+		v.oneOf("status", status, model.EmployeeStatuses...)
+	}
+	v.required("reason", reason)
+	v.maxLen("reason", reason, 500)
+	if err := v.err(); err != nil {
+		return model.Employee{}, err
+	}
+
+	scope := actor.Scope()
+	current, err := s.employees.GetEmployeeByID(id, false, scope)
+	if err != nil {
+		return model.Employee{}, mapRepoError(err)
+	}
+	if version != 0 && version != current.Version {
+		return model.Employee{}, mapRepoError(fmt.Errorf("сотрудник с id %d уже изменён: %w", id, database.ErrVersionMismatch))
+	}
+	if current.Status == status {
+		return view.Redact(current), nil
+	}
+	if !model.CanTransition(current.Status, status) {
+		return model.Employee{}, transitionError(current.Status, status)
+	}
+
+	changed := current
+	changed.Status = status
+	changed = statusDates(current, changed)
+	if err := validateEmployee(changed, &current); err != nil {
+		return model.Employee{}, err
+	}
+
+	meta := actor.meta()
+	meta.Reason = reason
+	fields := changedFields(current, changed, model.EmployeePatchFields)
+	updated, err := s.employees.PatchEmployee(id, current.Version, changed, fields, scope, meta)
+	if errors.Is(err, database.ErrStatusTransition) {
+		return model.Employee{}, s.transitionError(id, status)
+	}
+	return view.Redact(updated), mapRepoError(err)
+}
+
+// StatusHistory — история статусов сотрудника с причинами (доступна и после его удаления)
+func (s *EmployeeService) StatusHistory(actor Actor, id int64) ([]model.StatusChange, error) {
+	if !actor.Can(model.PermEmployeesHistory) {
+		return nil, forbidden("forbidden.status_history_view")
+	}
+	if !s.fields.View(actor).Visible("status") {
+		return nil, forbidden("forbidden.status_hidden")
+	}
+
+	history, err := s.employees.GetStatusHistory(id, actor.Scope())
+	return history, mapRepoError(err)
+}
+
+// statusDates — даты, которые следуют из смены статуса, если клиент не указал их сам:
+// при увольнении — дата увольнения (сегодня, но не раньше даты приёма), при приёме кандидата — дата приёма,
+// при повторном приёме — новая дата приёма, а прежние даты увольнения и испытательного срока сбрасываются.
+// Применяется при смене статуса через PATCH и /status; PUT записывает даты так, как их передал клиент.
+func statusDates(before, after model.Employee) model.Employee {
+	if before.Status == after.Status {
+		return after
+	}
+
+	today := model.Today()
+	switch after.Status {
+	case model.StatusTerminated:
+		if after.TerminationDate.IsZero() {
+			after.TerminationDate = today
+			if after.HireDate.After(today) {
+				after.TerminationDate = after.HireDate
+			}
+		}
+	case model.StatusOnboarding, model.StatusActive:
+		if before.Status == model.StatusTerminated && after.TerminationDate == before.TerminationDate {
+			after.TerminationDate = model.Date{}
+			if after.HireDate == before.HireDate {
+				after.HireDate = today
+			}
+			if after.ProbationEndDate == before.ProbationEndDate {
+				after.ProbationEndDate = model.Date{}
+			}
+		}
+		if (before.Status == model.StatusCandidate || before.Status == model.StatusTerminated) && after.HireDate.IsZero() {
+			after.HireDate = today
+		}
+	}
+	return after
+}
+
+// transitionError — ошибка поля status со списком статусов, в которые можно перейти из from
+func transitionError(from, to string) error {
+	return fieldError("status", "validation.status_transition", i18n.Args{
+		"from":   from,
+		"to":     to,
+		"values": strings.Join(model.StatusTransitions[from], ", "),
+	})
+}
+
+// transitionError — то же, когда текущий статус заранее не прочитан (хранилище отклонило смену)
+func (s *EmployeeService) transitionError(id int64, to string) error {
+	current, err := s.employees.GetEmployeeByID(id, false, model.Scope{})
+	if err != nil {
+		return mapRepoError(err)
+	}
+	return transitionError(current.Status, to)
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go.mod/internal/database"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"sort"
+	"strings"
+)
+
+// Минимальная длина пароля
+const minPasswordLength = 8
+
+// UserService — операции над учётными записями
+type UserService struct {
+	users       database.UserRepository
+	roles       database.RoleRepository
+	departments database.DepartmentRepository
+	tokens      database.TokenRepository
+	auth        *AuthService
+}
+
+func NewUserService(users database.UserRepository, roles database.RoleRepository, departments database.DepartmentRepository, tokens database.TokenRepository, auth *AuthService) *UserService {
+	return &UserService{users: users, roles: roles, departments: departments, tokens: tokens, auth: auth}
+}
+
+// Получить пользователя по ID (в том числе удалённого, если includeDeleted)
+func (s *UserService) Get(actor Actor, id int64, includeDeleted bool) (model.User, error) {
+	if !actor.Can(model.PermUsersRead) {
+		return model.User{}, forbidden("forbidden.users_view")
+	}
+
+	user, err := s.users.GetUserByID(id, includeDeleted)
+	return user, mapRepoError(err)
+}
+
+// Получить всех пользователей
+func (s *UserService) List(actor Actor, includeDeleted bool) ([]model.User, error) {
+	if !actor.Can(model.PermUsersRead) {
+		return nil, forbidden("forbidden.users_view")
+	}
+
+	users, err := s.users.GetAllUsers(includeDeleted)
+	return users, mapRepoError(err)
+}
+
+// Создать пользователя, возвращает его ID
+func (s *UserService) Create(actor Actor, user model.User) (int64, error) {
+	if !actor.Can(model.PermUsersWrite) {
+		return 0, forbidden("forbidden.users_create")
+	}
+
+	user = normalizeUser(user)
+	if err := validateUser(user, true); err != nil {
+		return 0, err
+	}
+	if err := s.checkRoles(actor, nil, user.Roles); err != nil {
+		return 0, err
+	}
+	if err := s.checkDepartment(user.DepartmentId); err != nil {
+		return 0, err
+	}
+
+	id, err := s.users.CreateUser(user, actor.meta())
+	return id, mapRepoError(err)
+}
+
+// Обновить пользователя, если его версия всё ещё равна version (0 — без проверки);
+// все его токены отзываются, так как логин, роли, отдел или пароль могли измениться.
+// Свои роли пользователь изменить не может, чтобы не лишить себя доступа по ошибке;
+// пароль и роли администратора меняет только администратор.
+func (s *UserService) Update(actor Actor, id int64, version int, user model.User) (model.User, error) {
+	if !actor.Can(model.PermUsersWrite) {
+		return model.User{}, forbidden("forbidden.users_update")
+	}
+
+	user = normalizeUser(user)
+	if err := validateUser(user, false); err != nil {
+		return model.User{}, err
+	}
+	if err := s.checkDepartment(user.DepartmentId); err != nil {
+		return model.User{}, err
+	}
+	current, err := s.users.GetUserByID(id, false)
+	if err != nil {
+		return model.User{}, mapRepoError(err)
+	}
+	if err := checkAdminAccount(actor, current, user.Password != "" || !sameStrings(current.Roles, user.Roles)); err != nil {
+		return model.User{}, err
+	}
+	if err := checkOwnRoles(actor, current, user.Roles); err != nil {
+		return model.User{}, err
+	}
+	if err := s.checkRoles(actor, current.Roles, user.Roles); err != nil {
+		return model.User{}, err
+	}
+
+	updated, err := s.users.UpdateUser(id, version, user, actor.meta())
+	if err != nil {
+		return model.User{}, mapRepoError(err)
+	}
+	return updated, mapRepoError(s.tokens.RevokeUserTokens(id))
+}
+
+// Удалить пользователя, если его версия всё ещё равна version (0 — без проверки);
+// токены отзываются до удаления, чтобы доступ пропал сразу
+func (s *UserService) Delete(actor Actor, id int64, version int) error {
+	if !actor.Can(model.PermUsersWrite) {
+		return forbidden("forbidden.users_delete")
+	}
+
+	user, err := s.users.GetUserByID(id, false)
+	if err != nil {
+		return mapRepoError(err)
+	}
+	if user.Username == actor.Username {
+		return forbidden("forbidden.users_delete_self")
+	}
+	// Проверяем версию заранее, чтобы не отзывать токены, если удаление всё равно не состоится
+	if version != 0 && version != user.Version {
+		return &domainError{kind: ErrPreconditionFailed, err: fmt.Errorf("%s: пользователь с id %d уже изменён", ErrPreconditionFailed, id), text: i18n.T("error.precondition_failed")}
+	}
+
+	if err := s.tokens.RevokeUserTokens(id); err != nil {
+		return mapRepoError(err)
+	}
+	return mapRepoError(s.users.DeleteUser(id, version, actor.meta()))
+}
+
+// Восстановить удалённого пользователя; 409, если его логин уже занят другим
+func (s *UserService) Restore(actor Actor, id int64) error {
+	if !actor.Can(model.PermUsersWrite) {
+		return forbidden("forbidden.users_restore")
+	}
+
+	return mapRepoError(s.users.RestoreUser(id, actor.meta()))
+}
+
+// Unlock — снять блокировку входа после неудачных попыток (и задержку после неудачи), сбросить их счётчик
+// и лимит попыток под логином пользователя
+func (s *UserService) Unlock(actor Actor, id int64) (model.User, error) {
+	if !actor.Can(model.PermUsersWrite) {
+		return model.User{}, forbidden("forbidden.users_unlock")
+	}
+
+	user, err := s.users.UnlockUser(id, actor.meta())
+	if err != nil {
+		return model.User{}, mapRepoError(err)
+	}
+	s.auth.ResetLoginLimit(user.Username)
+	return user, nil
+}
+
+// SetRoles — назначить пользователю роли вместо прежних; его токены отзываются,
+// чтобы новые разрешения действовали с первого же запроса. Роли администратора меняет только администратор.
+func (s *UserService) SetRoles(actor Actor, id int64, roles []string) (model.User, error) {
+	if !actor.Can(model.PermUsersWrite) {
+		return model.User{}, forbidden("forbidden.users_update")
+	}
+
+	roles = normalizeNames(roles)
+	if len(roles) == 0 {
+		return model.User{}, fieldError("roles", "validation.required")
+	}
+	current, err := s.users.GetUserByID(id, false)
+	if err != nil {
+		return model.User{}, mapRepoError(err)
+	}
+	if err := checkAdminAccount(actor, current, !sameStrings(current.Roles, roles)); err != nil {
+		return model.User{}, err
+	}
+	if err := checkOwnRoles(actor, current, roles); err != nil {
+		return model.User{}, err
+	}
+	if err := s.checkRoles(actor, current.Roles, roles); err != nil {
+		return model.User{}, err
+	}
+
+	updated, err := s.roles.SetUserRoles(id, roles, actor.meta())
+	if err != nil {
+		return model.User{}, mapRepoError(err)
+	}
+	return updated, mapRepoError(s.tokens.RevokeUserTokens(id))
+}
+
+// checkRoles — все роли существуют, а новые (которых нет среди current) пользователь вправе выдать:
+// администратор выдаёт любые, остальные — только роли, все разрешения которых есть у них самих.
+// Иначе с разрешением users:write можно было бы выдать себе (через вторую учётную запись) или другим
+// больше прав, чем есть у самого пользователя.
+func (s *UserService) checkRoles(actor Actor, current, roles []string) error {
+	existing, err := s.roles.GetRoles()
+	if err != nil {
+		return mapRepoError(err)
+	}
+	known := make(map[string]model.Role, len(existing))
+	for _, role := range existing {
+		known[role.Name] = role
+	}
+
+	var v validator
+	for _, name := range roles {
+		if _, ok := known[name]; !ok {
+			v.add("roles", "validation.role_not_found", i18n.Args{"role": name})
+		}
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	if actor.IsAdmin() {
+		return nil
+	}
+	for _, name := range roles {
+		if contains(current, name) {
+			continue
+		}
+		role := known[name]
+		if name == model.RoleAdmin {
+			role.Permissions = model.Permissions
+		}
+		for _, permission := range role.Permissions {
+			if !actor.Can(permission) {
+				return forbidden("forbidden.users_role_grant", i18n.Args{"role": name})
+			}
+		}
+	}
+	return nil
+}
+
+// checkAdminAccount — пароль и роли администратора (changed — они меняются) может менять только администратор,
+// иначе пользователь с users:write мог бы войти под учётной записью администратора или снять с неё роль
+func checkAdminAccount(actor Actor, current model.User, changed bool) error {
+	if changed && contains(current.Roles, model.RoleAdmin) && !actor.IsAdmin() {
+		return forbidden("forbidden.users_admin")
+	}
+	return nil
+}
+
+// checkDepartment — отдел пользователя существует (nil — отдел не назначен)
+func (s *UserService) checkDepartment(id *int) error {
+	if id == nil {
+		return nil
+	}
+	if _, err := s.departments.GetDepartmentByID(int64(*id)); errors.Is(err, database.ErrNotFound) {
+		return fieldError("department_id", "validation.department_not_found")
+	} else if err != nil {
+		return mapRepoError(err)
+	}
+	return nil
+}
+
+// checkOwnRoles — пользователь не меняет собственные роли
+func checkOwnRoles(actor Actor, current model.User, roles []string) error {
+	if current.Username == actor.Username && !sameStrings(current.Roles, roles) {
+		return forbidden("forbidden.users_roles_self")
+	}
+	return nil
+}
+
+// normalizeUser — логин без пробелов по краям, роли без повторов и по алфавиту (как их возвращает хранилище)
+func normalizeUser(u model.User) model.User {
+	u.Username = strings.TrimSpace(u.Username)
+	u.Roles = normalizeNames(u.Roles)
+	return u
+}
+
+// normalizeNames — имена ролей или разрешений без пробелов по краям, пустых, повторов и по алфавиту
+func normalizeNames(roles []string) []string {
+	seen := make(map[string]bool, len(roles))
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if role != "" && !seen[role] {
+			seen[role] = true
+			normalized = append(normalized, role)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// sameStrings — одинаковые наборы строк (оба отсортированы)
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validateUser — пароль обязателен только при создании, хотя бы одна роль — всегда
+func validateUser(u model.User, create bool) error {
+	var v validator
+	v.check(u)
+	if len(u.Roles) == 0 {
+		v.add("roles", "validation.required")
+	}
+	if create || u.Password != "" {
+		v.minLen("password", u.Password, minPasswordLength)
+	}
+	return v.err()
+}
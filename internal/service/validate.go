@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"go.mod/internal/messages"
+	"go.mod/internal/model"
+	"go.mod/pkg/i18n"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Перечисления для правила enum в тегах validate
+var enums = map[string][]string{
+	"employee_status": model.EmployeeStatuses,
+	"locale":          {messages.RU, messages.EN, messages.TG},
+}
+
+// validator — собирает ошибки по всем полям, чтобы вернуть их одним ответом
+type validator struct {
+	fields map[string]i18n.Text
+}
+
+// add — ошибка поля: ключ сообщения из каталога и его параметры
+func (v *validator) add(field, key string, args ...i18n.Args) {
+	if v.fields == nil {
+		v.fields = make(map[string]i18n.Text)
+	}
+	// Сохраняем первую ошибку поля
+	if _, ok := v.fields[field]; !ok {
+		v.fields[field] = i18n.T(key, args...)
+	}
+}
+
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, "validation.required")
+	}
+}
+
+func (v *validator) maxLen(field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.add(field, "validation.too_long", i18n.Args{"count": max})
+	}
+}
+
+func (v *validator) minLen(field, value string, min int) {
+	if utf8.RuneCountInString(value) < min {
+		v.add(field, "validation.too_short", i18n.Args{"count": min})
+	}
+}
+
+func (v *validator) email(field, value string) {
+	if value == "" {
+		return
+	}
+	if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+		v.add(field, "validation.email")
+	}
+}
+
+func (v *validator) date(field, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		v.add(field, "validation.date")
+	}
+}
+
+func (v *validator) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(field, "validation.one_of", i18n.Args{"values": strings.Join(allowed, ", ")})
+}
+
+// between — целое значение в диапазоне [min, max]
+func (v *validator) between(field string, value, min, max int) {
+	if value < min || value > max {
+		v.add(field, "validation.range", i18n.Args{"min": min, "max": max})
+	}
+}
+
+// E.164: "+", код страны и номер, всего не более 15 цифр
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// phone — номер телефона в формате E.164 (после normalizePhone)
+func (v *validator) phone(field, value string) {
+	if value != "" && !e164.MatchString(value) {
+		v.add(field, "validation.phone")
+	}
+}
+
+// normalizePhone — номер без пробелов, скобок, точек и дефисов; международный префикс 00 заменяется на "+".
+// "+992 (90) 123-45-67" -> "+992901234567"
+func normalizePhone(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '(', ')', '-', '.':
+			return -1
+		}
+		return r
+	}, value)
+	if strings.HasPrefix(value, "00") {
+		value = "+" + value[2:]
+	}
+	return value
+}
+
+// check — проверка строковых полей (и полей с методом String, например model.Date) по тегам validate:
+//
+//	required      — поле не пустое
+//	max=N, min=N  — длина в символах
+//	email         — адрес по RFC 5322
+//	phone         — номер в формате E.164
+//	date          — дата ГГГГ-ММ-ДД
+//	enum=name     — значение из перечисления enums[name]
+//
+// Все правила, кроме required, пропускают пустые значения. Имя поля в ошибке — из тега json.
+func (v *validator) check(s interface{}) {
+	rv := reflect.ValueOf(s)
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		field := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		value := rv.Field(i).String()
+		if stringer, ok := rv.Field(i).Interface().(fmt.Stringer); ok {
+			value = stringer.String()
+		}
+
+		for _, rule := range strings.Split(tag, ",") {
+			name, param, _ := strings.Cut(rule, "=")
+			switch name {
+			case "required":
+				v.required(field, value)
+			case "max":
+				v.maxLen(field, value, mustAtoi(rule, param))
+			case "min":
+				if value != "" {
+					v.minLen(field, value, mustAtoi(rule, param))
+				}
+			case "email":
+				v.email(field, value)
+			case "phone":
+				v.phone(field, value)
+			case "date":
+				v.date(field, value)
+			case "enum":
+				allowed, ok := enums[param]
+				if !ok {
+					panic(fmt.Sprintf("validate: неизвестное перечисление %q", param))
+				}
+				if value != "" {
+					v.oneOf(field, value, allowed...)
+				}
+			default:
+				panic(fmt.Sprintf("validate: неизвестное правило %q", rule))
+			}
+		}
+	}
+}
+
+// mustAtoi — числовой параметр правила; ошибка в теге — ошибка программиста
+func mustAtoi(rule, param string) int {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		panic(fmt.Sprintf("validate: некорректное правило %q", rule))
+	}
+	return n
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,136 @@
+// Package i18n — каталоги сообщений с подстановкой параметров, формами множественного числа
+// и выбором языка по заголовку Accept-Language
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message — сообщение каталога. У простого сообщения заполнено только Other;
+// формы One, Few и Many выбираются по параметру count согласно правилам языка.
+type Message struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+// Catalog — сообщения одного языка по ключам
+type Catalog map[string]Message
+
+// Args — именованные параметры сообщения: {name} в тексте заменяется значением
+type Args map[string]interface{}
+
+// Text — ключ сообщения с параметрами; переводится, когда известен язык ответа
+type Text struct {
+	Key  string
+	Args Args
+}
+
+// T — сообщение по ключу (параметры необязательны)
+func T(key string, args ...Args) Text {
+	text := Text{Key: key}
+	if len(args) > 0 {
+		text.Args = args[0]
+	}
+	return text
+}
+
+// Bundle — каталоги всех поддерживаемых языков и язык по умолчанию
+type Bundle struct {
+	fallback  string
+	languages []string
+	catalogs  map[string]Catalog
+}
+
+// NewBundle — набор каталогов; fallback используется для неподдерживаемых языков и отсутствующих ключей
+func NewBundle(fallback string) *Bundle {
+	return &Bundle{fallback: fallback, catalogs: make(map[string]Catalog)}
+}
+
+// Add — регистрирует каталог языка
+func (b *Bundle) Add(lang string, catalog Catalog) {
+	if _, ok := b.catalogs[lang]; !ok {
+		b.languages = append(b.languages, lang)
+	}
+	b.catalogs[lang] = catalog
+}
+
+// Supports — есть ли каталог для языка
+func (b *Bundle) Supports(lang string) bool {
+	_, ok := b.catalogs[lang]
+	return ok
+}
+
+// Fallback — язык по умолчанию
+func (b *Bundle) Fallback() string {
+	return b.fallback
+}
+
+// Translate — текст сообщения на языке lang (или на языке по умолчанию, если перевода нет);
+// неизвестный ключ возвращается как есть
+func (b *Bundle) Translate(lang string, text Text) string {
+	if !b.Supports(lang) {
+		lang = b.fallback
+	}
+	message, ok := b.catalogs[lang][text.Key]
+	if !ok {
+		if message, ok = b.catalogs[b.fallback][text.Key]; !ok {
+			return text.Key
+		}
+		lang = b.fallback
+	}
+
+	template := message.Other
+	if count, ok := toInt64(text.Args["count"]); ok {
+		template = message.form(PluralForm(lang, count))
+	}
+	return format(lang, template, text.Args)
+}
+
+// form — текст для формы множественного числа; недостающая форма заменяется на Other
+func (m Message) form(form string) string {
+	var s string
+	switch form {
+	case FormOne:
+		s = m.One
+	case FormFew:
+		s = m.Few
+	case FormMany:
+		s = m.Many
+	}
+	if s == "" {
+		return m.Other
+	}
+	return s
+}
+
+// format — подставляет {name}; целые числа форматируются по правилам языка
+func format(lang, template string, args Args) string {
+	if len(args) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+	pairs := make([]string, 0, len(args)*2)
+	for name, value := range args {
+		s := fmt.Sprint(value)
+		if n, ok := toInt64(value); ok {
+			s = FormatInt(lang, n)
+		}
+		pairs = append(pairs, "{"+name+"}", s)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch n := value.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case int32:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
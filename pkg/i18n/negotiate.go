@@ -0,0 +1,50 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiate — язык из заголовка Accept-Language (RFC 9110): варианты перебираются по убыванию q,
+// "en-US" и "tg-Cyrl-TJ" сводятся к основному подтегу. Если ничего не подошло — язык по умолчанию.
+func (b *Bundle) Negotiate(acceptLanguage string) string {
+	type option struct {
+		lang string
+		q    float64
+	}
+
+	var options []option
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.TrimSpace(name) == "q" {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		options = append(options, option{lang: tag, q: q})
+	}
+	sort.SliceStable(options, func(i, j int) bool { return options[i].q > options[j].q })
+
+	for _, o := range options {
+		if o.lang == "*" {
+			return b.fallback
+		}
+		primary, _, _ := strings.Cut(o.lang, "-")
+		if b.Supports(primary) {
+			return primary
+		}
+	}
+	return b.fallback
+}
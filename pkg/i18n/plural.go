@@ -0,0 +1,63 @@
+package i18n
+
+import "strconv"
+
+// Формы множественного числа (категории CLDR)
+const (
+	FormOne   = "one"
+	FormFew   = "few"
+	FormMany  = "many"
+	FormOther = "other"
+)
+
+// PluralForm — форма множественного числа для целого n.
+// Русский: 1, 21 — one; 2–4, 22–24 — few; 0, 5–20, 25 — many.
+// Английский и таджикский: 1 — one, остальное — other.
+func PluralForm(lang string, n int64) string {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case "ru":
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return FormOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return FormFew
+		default:
+			return FormMany
+		}
+	default:
+		if n == 1 {
+			return FormOne
+		}
+		return FormOther
+	}
+}
+
+// FormatInt — целое число с разделителем разрядов языка: неразрывный пробел (ru, tg) или запятая (en).
+// Четырёхзначные числа в русском и таджикском не разделяются.
+func FormatInt(lang string, n int64) string {
+	s := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+
+	separator := " "
+	if lang == "en" {
+		separator = ","
+	} else if len(s) <= 4 {
+		return sign + s
+	}
+
+	var out []byte
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, separator...)
+		}
+		out = append(out, s[i])
+	}
+	return sign + string(out)
+}
@@ -0,0 +1,338 @@
+// Package jsonpatch — применение JSON Merge Patch (RFC 7396) и JSON Patch (RFC 6902) к JSON-документу
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Патч некорректен или не может быть применён к документу
+	ErrInvalidPatch = errors.New("некорректный патч")
+	// Операция test не прошла: документ отличается от ожидаемого
+	ErrTestFailed = errors.New("проверка test не прошла")
+)
+
+// MergePatch — применяет merge patch (RFC 7396): объекты сливаются рекурсивно, null удаляет ключ,
+// любое другое значение заменяет прежнее целиком
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergeValue(target, p))
+}
+
+func mergeValue(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = mergeValue(t[key], value)
+		}
+	}
+	return t
+}
+
+// Operation — одна операция JSON Patch
+type Operation struct {
+	Op       string          `json:"op"`
+	Path     string          `json:"path"`
+	From     string          `json:"from,omitempty"`
+	Value    json.RawMessage `json:"value,omitempty"`
+	HasValue bool            `json:"-"` // Ключ value указан; "value": null — тоже значение (например, чтобы очистить поле)
+}
+
+// UnmarshalJSON — разбирает операцию и отмечает, указан ли ключ value: по самому Value
+// нельзя отличить отсутствующее value от "value": null
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type operation Operation
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*operation)(o)); err != nil {
+		return err
+	}
+	o.Value, o.HasValue = fields["value"]
+	return nil
+}
+
+// Apply — применяет JSON Patch (RFC 6902): операции add, remove, replace, move, copy и test
+// выполняются по порядку; при первой ошибке документ не изменяется
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: ожидается массив операций: %v", ErrInvalidPatch, err)
+	}
+
+	for i, op := range ops {
+		if target, err = applyOperation(target, op); err != nil {
+			return nil, fmt.Errorf("операция %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func applyOperation(doc interface{}, op Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if !op.HasValue {
+			return nil, fmt.Errorf("%w: не указано value", ErrInvalidPatch)
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if _, err := get(doc, path); err != nil {
+				return nil, err
+			}
+			if doc, _, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if op.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: нельзя переместить значение внутрь самого себя", ErrInvalidPatch)
+			}
+			if doc, value, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = get(doc, from); err != nil {
+				return nil, err
+			}
+			value = clone(value)
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: неизвестная операция %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer — JSON Pointer (RFC 6901): "/a/b~1c" -> ["a", "b/c"]; "" — весь документ
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: путь %q должен начинаться с /", ErrInvalidPatch, pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i, part := range parts {
+		parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+	}
+	return parts, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// get — значение по пути
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, key := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("%w: поле %q не найдено", ErrInvalidPatch, key)
+			}
+			doc = value
+		case []interface{}:
+			i, err := arrayIndex(key, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%w: путь ведёт внутрь скалярного значения", ErrInvalidPatch)
+		}
+	}
+	return doc, nil
+}
+
+// add — добавляет или заменяет значение в объекте, вставляет элемент в массив ("-" — в конец)
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[key] = value
+		return doc, nil
+	case []interface{}:
+		i := len(node)
+		if key != "-" {
+			if i, err = arrayIndex(key, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[i+1:], node[i:])
+		node[i] = value
+		return setParent(doc, path[:len(path)-1], node)
+	default:
+		return nil, fmt.Errorf("%w: путь ведёт внутрь скалярного значения", ErrInvalidPatch)
+	}
+}
+
+// remove — удаляет значение по пути и возвращает его
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	key := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: поле %q не найдено", ErrInvalidPatch, key)
+		}
+		delete(node, key)
+		return doc, value, nil
+	case []interface{}:
+		i, err := arrayIndex(key, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[i]
+		node = append(node[:i:i], node[i+1:]...)
+		doc, err = setParent(doc, path[:len(path)-1], node)
+		return doc, value, err
+	default:
+		return nil, nil, fmt.Errorf("%w: путь ведёт внутрь скалярного значения", ErrInvalidPatch)
+	}
+}
+
+// setParent — записывает изменённый массив обратно (append мог создать новый срез)
+func setParent(doc interface{}, path []string, array []interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return array, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[key] = array
+	case []interface{}:
+		i, _ := strconv.Atoi(key)
+		node[i] = array
+	}
+	return doc, nil
+}
+
+// arrayIndex — индекс элемента массива от 0 до max; ведущие нули запрещены (RFC 6901)
+func arrayIndex(key string, max int) (int, error) {
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 || i > max || (len(key) > 1 && key[0] == '0') {
+		return 0, fmt.Errorf("%w: некорректный индекс массива %q", ErrInvalidPatch, key)
+	}
+	return i, nil
+}
+
+// decode — JSON в map/slice/скаляры; числа сохраняются как json.Number, чтобы не терять точность
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("после JSON-значения есть лишние данные")
+	}
+	return value, nil
+}
+
+// clone — глубокая копия значения (для copy, чтобы источник и копия не разделяли данные)
+func clone(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			c[key] = clone(item)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = clone(item)
+		}
+		return c
+	default:
+		return v
+	}
+}
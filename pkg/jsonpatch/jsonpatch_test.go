@@ -0,0 +1,116 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string // Ожидаемый документ; пусто — ожидается ошибка err
+		err   error
+	}{
+		// value: null — обычное значение, а не его отсутствие
+		{"add null", `{"a":1}`, `[{"op":"add","path":"/b","value":null}]`, `{"a":1,"b":null}`, nil},
+		{"replace null", `{"manager_id":5}`, `[{"op":"replace","path":"/manager_id","value":null}]`, `{"manager_id":null}`, nil},
+		{"test null", `{"manager_id":null}`, `[{"op":"test","path":"/manager_id","value":null}]`, `{"manager_id":null}`, nil},
+		{"test null fails", `{"manager_id":5}`, `[{"op":"test","path":"/manager_id","value":null}]`, "", ErrTestFailed},
+		{"add without value", `{"a":1}`, `[{"op":"add","path":"/b"}]`, "", ErrInvalidPatch},
+		{"replace without value", `{"a":1}`, `[{"op":"replace","path":"/a"}]`, "", ErrInvalidPatch},
+		{"test without value", `{"a":null}`, `[{"op":"test","path":"/a"}]`, "", ErrInvalidPatch},
+
+		// Индексы массивов
+		{"add to end", `{"a":[1,2]}`, `[{"op":"add","path":"/a/-","value":3}]`, `{"a":[1,2,3]}`, nil},
+		{"add to end of empty", `{"a":[]}`, `[{"op":"add","path":"/a/-","value":1}]`, `{"a":[1]}`, nil},
+		{"insert by index", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`, nil},
+		{"insert at length", `{"a":[1]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2]}`, nil},
+		{"insert past length", `{"a":[1]}`, `[{"op":"add","path":"/a/2","value":2}]`, "", ErrInvalidPatch},
+		{"replace end", `{"a":[1]}`, `[{"op":"replace","path":"/a/-","value":2}]`, "", ErrInvalidPatch},
+		{"remove end", `{"a":[1]}`, `[{"op":"remove","path":"/a/-"}]`, "", ErrInvalidPatch},
+		{"leading zero", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/01"}]`, "", ErrInvalidPatch},
+		{"remove by index", `{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/1"}]`, `{"a":[1,3]}`, nil},
+
+		// Экранирование в JSON Pointer: ~1 — "/", ~0 — "~"
+		{"escaped slash", `{"a/b":1}`, `[{"op":"replace","path":"/a~1b","value":2}]`, `{"a/b":2}`, nil},
+		{"escaped tilde", `{"a~b":1}`, `[{"op":"remove","path":"/a~0b"}]`, `{}`, nil},
+		{"tilde then one", `{"~1":1,"/":2}`, `[{"op":"remove","path":"/~01"}]`, `{"/":2}`, nil},
+
+		// Прочие операции
+		{"move", `{"a":1}`, `[{"op":"move","from":"/a","path":"/b"}]`, `{"b":1}`, nil},
+		{"move into itself", `{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`, "", ErrInvalidPatch},
+		{"copy", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"}]`, `{"a":{"b":1},"c":{"b":1}}`, nil},
+		{"remove missing", `{"a":1}`, `[{"op":"remove","path":"/b"}]`, "", ErrInvalidPatch},
+		{"unknown op", `{"a":1}`, `[{"op":"increment","path":"/a"}]`, "", ErrInvalidPatch},
+		{"not an array", `{"a":1}`, `{"op":"remove","path":"/a"}`, "", ErrInvalidPatch},
+		{"large number", `{"a":1}`, `[{"op":"replace","path":"/a","value":12345678901234567890}]`, `{"a":12345678901234567890}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("ошибка %v, ожидалась %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("получено %s, ожидалось %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"replace", `{"a":1,"b":2}`, `{"a":3}`, `{"a":3,"b":2}`},
+		{"null removes key", `{"a":1,"b":2}`, `{"a":null}`, `{"b":2}`},
+		{"nested", `{"a":{"b":1,"c":2}}`, `{"a":{"b":null,"d":3}}`, `{"a":{"c":2,"d":3}}`},
+		{"array replaced whole", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`},
+		{"non-object patch", `{"a":1}`, `[1]`, `[1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("получено %s, ожидалось %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperationHasValue(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{`{"op":"add","path":"/a","value":null}`, true},
+		{`{"op":"add","path":"/a","value":0}`, true},
+		{`{"op":"add","path":"/a"}`, false},
+	}
+	for _, tt := range tests {
+		var op Operation
+		if err := json.Unmarshal([]byte(tt.data), &op); err != nil {
+			t.Fatalf("%s: %v", tt.data, err)
+		}
+		if op.HasValue != tt.want {
+			t.Errorf("%s: HasValue = %v, ожидалось %v", tt.data, op.HasValue, tt.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Параметры argon2id (RFC 9106, второй рекомендуемый вариант: 64 МиБ памяти, 3 прохода)
+const (
+	argonTime    uint32 = 3
+	argonMemory  uint32 = 64 * 1024
+	argonThreads uint8  = 2
+	argonKeyLen  uint32 = 32
+	argonSaltLen        = 16
+)
+
+// Prefix — префикс хеша, по которому определяется алгоритм
+const Prefix = "$argon2id$"
+
+var ErrInvalidHash = errors.New("некорректный формат хеша пароля")
+
+// Hash — возвращает хеш пароля в формате $argon2id$v=19$m=...,t=...,p=...$соль$хеш
+func Hash(plain string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("ошибка генерации соли: %v", err)
+	}
+
+	key := argon2.IDKey([]byte(plain), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		Prefix,
+		argon2.Version,
+		argonMemory,
+		argonTime,
+		argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify — сравнивает пароль с сохранённым значением за постоянное время.
+// needsRehash = true, если значение нужно перезаписать новым хешем:
+// старый пароль в открытом виде, bcrypt или устаревшие параметры argon2id.
+func Verify(plain, stored string) (ok bool, needsRehash bool) {
+	switch {
+	case strings.HasPrefix(stored, Prefix):
+		return verifyArgon2id(plain, stored)
+	case isBcrypt(stored):
+		if bcrypt.CompareHashAndPassword([]byte(stored), []byte(plain)) != nil {
+			return false, false
+		}
+		return true, true
+	default:
+		// Старые записи, где пароль хранился в открытом виде
+		if subtle.ConstantTimeCompare([]byte(plain), []byte(stored)) != 1 {
+			return false, false
+		}
+		return true, true
+	}
+}
+
+// IsHashed — проверяет, что значение уже является хешем, а не паролем в открытом виде
+func IsHashed(stored string) bool {
+	return strings.HasPrefix(stored, Prefix) || isBcrypt(stored)
+}
+
+func isBcrypt(stored string) bool {
+	return strings.HasPrefix(stored, "$2a$") || strings.HasPrefix(stored, "$2b$") || strings.HasPrefix(stored, "$2y$")
+}
+
+func verifyArgon2id(plain, stored string) (bool, bool) {
+	version, memory, time, threads, salt, key, err := decodeArgon2id(stored)
+	if err != nil || version != argon2.Version {
+		return false, false
+	}
+
+	other := argon2.IDKey([]byte(plain), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, false
+	}
+
+	outdated := memory != argonMemory || time != argonTime || threads != argonThreads || uint32(len(key)) != argonKeyLen
+	return true, outdated
+}
+
+func decodeArgon2id(stored string) (version int, memory, time uint32, threads uint8, salt, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", соль, хеш
+	parts := strings.Split(stored, "$")
+	if len(parts) != 6 {
+		err = ErrInvalidHash
+		return
+	}
+
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		err = ErrInvalidHash
+		return
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		err = ErrInvalidHash
+		return
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		err = ErrInvalidHash
+		return
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		err = ErrInvalidHash
+		return
+	}
+	return
+}
@@ -0,0 +1,75 @@
+// Package ratelimit — ограничение числа событий по ключу (логину, IP-адресу) в скользящем окне
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Window — не более limit событий на ключ за последние period. Хранит время каждого события,
+// поэтому окно действительно скользящее, без всплеска на границе интервалов.
+// Состояние в памяти процесса: при нескольких экземплярах сервера лимит действует на каждый отдельно.
+type Window struct {
+	limit  int
+	period time.Duration
+
+	mu        sync.Mutex
+	events    map[string][]time.Time
+	lastSweep time.Time
+}
+
+// NewWindow — окно на limit событий за period; limit <= 0 — без ограничения
+func NewWindow(limit int, period time.Duration) *Window {
+	return &Window{limit: limit, period: period, events: make(map[string][]time.Time)}
+}
+
+// Allow — учитывает событие по ключу key, если лимит ещё не исчерпан. Иначе событие не учитывается,
+// а retryAfter — через сколько в окне освободится место.
+func (w *Window) Allow(key string, now time.Time) (ok bool, retryAfter time.Duration) {
+	if w.limit <= 0 {
+		return true, 0
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.sweep(now)
+	events := w.recent(key, now)
+	if len(events) >= w.limit {
+		w.events[key] = events
+		return false, events[0].Add(w.period).Sub(now)
+	}
+	w.events[key] = append(events, now)
+	return true, 0
+}
+
+// Reset — забывает события по ключу key: лимит для него начинается заново
+func (w *Window) Reset(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.events, key)
+}
+
+// recent — события по ключу, ещё попадающие в окно (от старых к новым)
+func (w *Window) recent(key string, now time.Time) []time.Time {
+	events := w.events[key]
+	start := now.Add(-w.period)
+	i := 0
+	for i < len(events) && !events[i].After(start) {
+		i++
+	}
+	return events[i:]
+}
+
+// sweep — раз в период удаляет ключи без событий в окне, чтобы карта не росла от разовых ключей
+func (w *Window) sweep(now time.Time) {
+	if now.Sub(w.lastSweep) < w.period {
+		return
+	}
+	w.lastSweep = now
+	for key := range w.events {
+		if len(w.recent(key, now)) == 0 {
+			delete(w.events, key)
+		}
+	}
+}